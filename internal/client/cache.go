@@ -0,0 +1,207 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cache stores successful GET responses on disk. Only requests whose
+// endAt query parameter lies in the past are cached, since results for
+// ranges that are still running keep changing. Entries are keyed by
+// method, URL and a hash of the token, so tokens never share entries.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+type CacheStats struct {
+	Dir     string `json:"dir"`
+	Entries int    `json:"entries"`
+	Expired int    `json:"expired"`
+	Bytes   int64  `json:"bytes"`
+}
+
+type cacheEntry struct {
+	URL      string    `json:"url"`
+	StoredAt time.Time `json:"storedAt"`
+	Body     []byte    `json:"body"`
+}
+
+const cacheExt = ".json"
+
+// SettleWindow is how long a range must have ended before its results are
+// treated as final and cached. Ranges ending "now" (e.g. --since 7d) are
+// still receiving events, and recently ended ones can still gain late or
+// backdated events.
+const SettleWindow = 6 * time.Hour
+
+// NewCache returns a cache in dir, or in DefaultCacheDir when dir is empty.
+func NewCache(dir string, ttl time.Duration) (*Cache, error) {
+	if dir == "" {
+		var err error
+		if dir, err = DefaultCacheDir(); err != nil {
+			return nil, err
+		}
+	}
+	return &Cache{Dir: dir, TTL: ttl}, nil
+}
+
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "umami-cli", "http"), nil
+}
+
+// WithCache serves repeated GET requests for past ranges from cache.
+func WithCache(cache *Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// Stats counts the cached entries and their size on disk.
+func (c *Cache) Stats() (CacheStats, error) {
+	stats := CacheStats{Dir: c.Dir}
+	err := c.walk(func(path string, info os.FileInfo) error {
+		stats.Entries++
+		stats.Bytes += info.Size()
+		if c.expired(info.ModTime()) {
+			stats.Expired++
+		}
+		return nil
+	})
+	return stats, err
+}
+
+// Clear removes cached entries, or only expired ones when expiredOnly is
+// set, and returns how many were removed.
+func (c *Cache) Clear(expiredOnly bool) (int, error) {
+	removed := 0
+	err := c.walk(func(path string, info os.FileInfo) error {
+		if expiredOnly && !c.expired(info.ModTime()) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
+func (c *Cache) walk(fn func(path string, info os.FileInfo) error) error {
+	entries, err := os.ReadDir(c.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), cacheExt) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		if err := fn(filepath.Join(c.Dir, e.Name()), info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cache) expired(storedAt time.Time) bool {
+	return time.Since(storedAt) > c.TTL
+}
+
+func (c *Cache) key(method string, u *url.URL, token string) string {
+	tokenHash := sha256.Sum256([]byte(token))
+	sum := sha256.Sum256([]byte(method + " " + u.String() + "\n" + hex.EncodeToString(tokenHash[:])))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Cache) get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(filepath.Join(c.Dir, key+cacheExt))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || c.expired(entry.StoredAt) {
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+// put writes an entry atomically. Failures are ignored: the cache is only
+// an optimisation.
+func (c *Cache) put(key string, u *url.URL, body []byte) {
+	data, err := json.Marshal(cacheEntry{URL: u.String(), StoredAt: time.Now(), Body: body})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(c.Dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err != nil || closeErr != nil {
+		return
+	}
+	os.Rename(tmp.Name(), filepath.Join(c.Dir, key+cacheExt))
+}
+
+// cacheable reports whether a request may be served from cache: a GET
+// whose endAt lies at least SettleWindow in the past.
+func cacheable(method string, u *url.URL) bool {
+	if method != http.MethodGet {
+		return false
+	}
+	endAt, err := strconv.ParseInt(u.Query().Get("endAt"), 10, 64)
+	if err != nil {
+		return false
+	}
+	return time.UnixMilli(endAt).Before(time.Now().Add(-SettleWindow))
+}
+
+// cachedResponse builds the response returned for a cache hit.
+func cachedResponse(req *http.Request, body []byte) *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}
+}
+
+// store saves a successful response and returns it with a fresh body.
+func (c *Cache) store(key string, resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, resp.Request.URL, body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
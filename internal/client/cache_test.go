@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func endAtPath(endAt time.Time) string {
+	return "/websites/w1/stats?endAt=" + strconv.FormatInt(endAt.UnixMilli(), 10) + "&startAt=0"
+}
+
+func TestCacheable(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		query  string
+		want   bool
+	}{
+		{"ended long ago", http.MethodGet, "endAt=1704067200000", true},
+		{"ended just now", http.MethodGet, "endAt=" + strconv.FormatInt(time.Now().Add(-time.Minute).UnixMilli(), 10), false},
+		{"ended within settle window", http.MethodGet, "endAt=" + strconv.FormatInt(time.Now().Add(-SettleWindow/2).UnixMilli(), 10), false},
+		{"ends in the future", http.MethodGet, "endAt=" + strconv.FormatInt(time.Now().Add(time.Hour).UnixMilli(), 10), false},
+		{"post", http.MethodPost, "endAt=1704067200000", false},
+		{"no endAt", http.MethodGet, "startAt=1704067200000", false},
+		{"invalid endAt", http.MethodGet, "endAt=yesterday", false},
+	}
+	for _, tt := range tests {
+		u := &url.URL{Scheme: "https", Host: "example.com", Path: "/api/websites/w1/stats", RawQuery: tt.query}
+		if got := cacheable(tt.method, u); got != tt.want {
+			t.Errorf("%s: cacheable = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	c := &Cache{}
+	u, _ := url.Parse("https://example.com/api/websites/w1/stats?endAt=1")
+	other, _ := url.Parse("https://example.com/api/websites/w2/stats?endAt=1")
+
+	key := c.key(http.MethodGet, u, "token-a")
+	if key != c.key(http.MethodGet, u, "token-a") {
+		t.Error("key is not stable")
+	}
+	for name, k := range map[string]string{
+		"token":  c.key(http.MethodGet, u, "token-b"),
+		"method": c.key(http.MethodHead, u, "token-a"),
+		"url":    c.key(http.MethodGet, other, "token-a"),
+	} {
+		if k == key {
+			t.Errorf("key does not depend on the %s", name)
+		}
+	}
+}
+
+// cacheServer counts requests and answers with status and a body holding
+// the request count.
+func cacheServer(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.WriteHeader(status)
+		w.Write([]byte(`{"n":` + strconv.Itoa(int(n)) + `}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestCacheHitAndMiss(t *testing.T) {
+	srv, requests := cacheServer(t, http.StatusOK)
+	cache := &Cache{Dir: t.TempDir(), TTL: time.Hour}
+	c, err := New(srv.URL, "token-a", WithCache(cache), WithRetry(RetryPolicy{MaxAttempts: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	past := endAtPath(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	recent := endAtPath(time.Now().Add(-time.Minute))
+
+	get := func(c *Client, p string) int {
+		t.Helper()
+		var out struct{ N int }
+		if _, err := c.Do(context.Background(), http.MethodGet, p, nil, &out, true); err != nil {
+			t.Fatal(err)
+		}
+		return out.N
+	}
+
+	if n := get(c, past); n != 1 {
+		t.Fatalf("first request got response %d, want 1", n)
+	}
+	if n := get(c, past); n != 1 {
+		t.Errorf("repeated request got response %d, want the cached 1", n)
+	}
+	if n := get(c.WithToken("token-b"), past); n != 2 {
+		t.Errorf("other token got response %d, want a fresh 2", n)
+	}
+	get(c, recent)
+	get(c, recent)
+	if got := requests.Load(); got != 4 {
+		t.Errorf("got %d requests, want 4", got)
+	}
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Entries != 2 || stats.Expired != 0 || stats.Bytes == 0 {
+		t.Errorf("got stats %+v, want 2 live entries", stats)
+	}
+
+	cache.TTL = -time.Second
+	if n := get(c, past); n != 5 {
+		t.Errorf("expired entry got response %d, want a fresh 5", n)
+	}
+	if removed, err := cache.Clear(true); err != nil || removed != 2 {
+		t.Errorf("Clear(expired) removed %d (%v), want 2", removed, err)
+	}
+	if stats, _ := cache.Stats(); stats.Entries != 0 {
+		t.Errorf("got %d entries after clear, want 0", stats.Entries)
+	}
+}
+
+func TestCacheSkipsErrors(t *testing.T) {
+	srv, requests := cacheServer(t, http.StatusNotFound)
+	cache := &Cache{Dir: t.TempDir(), TTL: time.Hour}
+	c, err := New(srv.URL, "token", WithCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := endAtPath(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	for range 2 {
+		if _, err := c.Do(context.Background(), http.MethodGet, p, nil, nil, true); err == nil {
+			t.Fatal("got no error, want 404")
+		}
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+}
@@ -19,9 +19,13 @@ type Client struct {
 	baseURL    *url.URL
 	token      string
 	httpClient *http.Client
+	retry      RetryPolicy
+	timeout    time.Duration
+	userAgent  string
+	cache      *Cache
 }
 
-func New(endpoint, token string) (*Client, error) {
+func New(endpoint, token string, opts ...Option) (*Client, error) {
 	if endpoint == "" {
 		return nil, errors.New("endpoint required")
 	}
@@ -35,13 +39,24 @@ func New(endpoint, token string) (*Client, error) {
 	}
 	parsed.Path = strings.TrimRight(parsed.Path, "/")
 
-	return &Client{
+	c := &Client{
 		baseURL: parsed,
 		token:   token,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-	}, nil
+		retry: DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.timeout > 0 {
+		// Copy so that a client passed to WithHTTPClient is left untouched.
+		httpClient := *c.httpClient
+		httpClient.Timeout = c.timeout
+		c.httpClient = &httpClient
+	}
+	return c, nil
 }
 
 func (c *Client) WithToken(token string) *Client {
@@ -50,118 +65,161 @@ func (c *Client) WithToken(token string) *Client {
 	return &clone
 }
 
+// WithUserAgent returns a copy of the client that sends ua as the
+// User-Agent header.
+func (c *Client) WithUserAgent(ua string) *Client {
+	clone := *c
+	clone.userAgent = ua
+	return &clone
+}
+
 func (c *Client) Do(ctx context.Context, method, p string, body any, out any, auth bool) (int, error) {
-	url, err := c.buildURL(p)
+	resp, err := c.send(ctx, method, p, body, auth)
 	if err != nil {
 		return 0, err
 	}
+	defer resp.Body.Close()
 
-	var reader io.Reader
-	if body != nil {
-		buf := &bytes.Buffer{}
-		if err := json.NewEncoder(buf).Encode(body); err != nil {
-			return 0, err
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 8192))
+		if debugEnabled() {
+			fmt.Fprintf(os.Stderr, "debug: http response body=%s\n", truncateBody(bodyBytes))
 		}
-		reader = buf
+		return resp.StatusCode, newAPIError(method, resp.Request.URL.String(), resp.StatusCode, bodyBytes)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url.String(), reader)
-	if err != nil {
-		return 0, err
-	}
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	if auth {
-		if c.token == "" {
-			return 0, errors.New("missing token: run `umami auth login` or set UMAMI_TOKEN")
-		}
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	if out == nil {
+		return resp.StatusCode, nil
 	}
-	if out != nil {
-		req.Header.Set("Accept", "application/json")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
 	}
-
 	if debugEnabled() {
-		fmt.Fprintf(os.Stderr, "debug: http request method=%s url=%s auth=%t token-set=%t token-len=%d\n",
-			method, url.String(), auth, c.token != "", len(c.token))
+		fmt.Fprintf(os.Stderr, "debug: http response body=%s\n", truncateBody(bodyBytes))
 	}
+	if err := json.Unmarshal(bodyBytes, out); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+func (c *Client) DoRaw(ctx context.Context, method, p string, body any, auth bool) (int, []byte, error) {
+	resp, err := c.send(ctx, method, p, body, auth)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 	defer resp.Body.Close()
 
-	if debugEnabled() {
-		fmt.Fprintf(os.Stderr, "debug: http response status=%d url=%s\n", resp.StatusCode, url.String())
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, err
 	}
 
 	if resp.StatusCode >= 400 {
-		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 8192))
-		return resp.StatusCode, fmt.Errorf("request failed (%d): %s", resp.StatusCode, strings.TrimSpace(string(bodyBytes)))
+		return resp.StatusCode, bodyBytes, newAPIError(method, resp.Request.URL.String(), resp.StatusCode, bodyBytes)
 	}
-
-	if out == nil {
-		return resp.StatusCode, nil
-	}
-	return resp.StatusCode, json.NewDecoder(resp.Body).Decode(out)
+	return resp.StatusCode, bodyBytes, nil
 }
 
-func (c *Client) DoRaw(ctx context.Context, method, p string, body any, auth bool) (int, []byte, error) {
+// send performs the request, retrying idempotent methods according to the
+// client's retry policy. The caller must close the returned body.
+func (c *Client) send(ctx context.Context, method, p string, body any, auth bool) (*http.Response, error) {
 	url, err := c.buildURL(p)
 	if err != nil {
-		return 0, nil, err
+		return nil, err
+	}
+	if auth && c.token == "" {
+		return nil, ErrMissingToken
 	}
 
-	var reader io.Reader
+	var payload []byte
 	if body != nil {
 		buf := &bytes.Buffer{}
 		if err := json.NewEncoder(buf).Encode(body); err != nil {
-			return 0, nil, err
+			return nil, err
 		}
-		reader = buf
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, url.String(), reader)
-	if err != nil {
-		return 0, nil, err
-	}
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	if auth {
-		if c.token == "" {
-			return 0, nil, errors.New("missing token: run `umami auth login` or set UMAMI_TOKEN")
+		payload = buf.Bytes()
+	}
+
+	var cacheKey string
+	if c.cache != nil && cacheable(method, url) {
+		cacheKey = c.cache.key(method, url, c.token)
+		if cached, ok := c.cache.get(cacheKey); ok {
+			if debugEnabled() {
+				fmt.Fprintf(os.Stderr, "debug: http cache hit url=%s\n", url.String())
+			}
+			req, err := http.NewRequestWithContext(ctx, method, url.String(), nil)
+			if err != nil {
+				return nil, err
+			}
+			return cachedResponse(req, cached), nil
 		}
-		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
-	req.Header.Set("Accept", "application/json")
 
-	if debugEnabled() {
-		fmt.Fprintf(os.Stderr, "debug: http request method=%s url=%s auth=%t token-set=%t token-len=%d\n",
-			method, url.String(), auth, c.token != "", len(c.token))
+	attempts := 1
+	if idempotent(method) && c.retry.MaxAttempts > 1 {
+		attempts = c.retry.MaxAttempts
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return 0, nil, err
-	}
-	defer resp.Body.Close()
+	for attempt := 1; ; attempt++ {
+		var reader io.Reader
+		if payload != nil {
+			reader = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url.String(), reader)
+		if err != nil {
+			return nil, err
+		}
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if auth {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		req.Header.Set("Accept", "application/json")
+		if c.userAgent != "" {
+			req.Header.Set("User-Agent", c.userAgent)
+		}
 
-	if debugEnabled() {
-		fmt.Fprintf(os.Stderr, "debug: http response status=%d url=%s\n", resp.StatusCode, url.String())
-	}
+		if debugEnabled() {
+			fmt.Fprintf(os.Stderr, "debug: http request method=%s url=%s auth=%t token-set=%t token-len=%d attempt=%d\n",
+				method, url.String(), auth, c.token != "", len(c.token), attempt)
+		}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return resp.StatusCode, nil, err
-	}
+		resp, err := c.httpClient.Do(req)
+		if err == nil && debugEnabled() {
+			fmt.Fprintf(os.Stderr, "debug: http response status=%d url=%s\n", resp.StatusCode, url.String())
+		}
 
-	if resp.StatusCode >= 400 {
-		return resp.StatusCode, bodyBytes, fmt.Errorf("request failed (%d): %s", resp.StatusCode, strings.TrimSpace(string(bodyBytes)))
+		retry := attempt < attempts
+		if err != nil {
+			retry = retry && retryableError(ctx, err)
+		} else {
+			retry = retry && retryableStatus(resp.StatusCode)
+		}
+		var delay time.Duration
+		if retry {
+			delay, retry = c.retry.backoff(attempt, resp)
+		}
+		if !retry {
+			if err == nil && cacheKey != "" && resp.StatusCode == http.StatusOK {
+				return c.cache.store(cacheKey, resp)
+			}
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 8192))
+			resp.Body.Close()
+		}
+		if debugEnabled() {
+			fmt.Fprintf(os.Stderr, "debug: http retry in %s url=%s\n", delay, url.String())
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
 	}
-	return resp.StatusCode, bodyBytes, nil
 }
 
 func (c *Client) buildURL(p string) (*url.URL, error) {
@@ -184,3 +242,11 @@ func (c *Client) buildURL(p string) (*url.URL, error) {
 func debugEnabled() bool {
 	return strings.EqualFold(strings.TrimSpace(os.Getenv("DEBUG")), "true")
 }
+
+func truncateBody(body []byte) string {
+	const max = 2048
+	if len(body) <= max {
+		return string(body)
+	}
+	return string(body[:max]) + "...(truncated)"
+}
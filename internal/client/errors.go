@@ -0,0 +1,125 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var ErrMissingToken = errors.New("missing token: run `umami auth login` or set UMAMI_TOKEN")
+
+// APIError is returned for responses with a 4xx or 5xx status. Use
+// errors.As to inspect it.
+type APIError struct {
+	StatusCode int
+	// Code is the machine-readable error code sent by Umami, if any.
+	Code    string
+	Message string
+	Method  string
+	URL     string
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.StatusCode == http.StatusNotFound {
+		if resource := e.resource(); resource != "" {
+			return fmt.Sprintf("%s not found (404): %s", resource, msg)
+		}
+	}
+	return fmt.Sprintf("request failed (%d): %s", e.StatusCode, msg)
+}
+
+// Hint suggests how to resolve the error, or returns "" when there is
+// nothing more useful to say than the message itself.
+func (e *APIError) Hint() string {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		return "token expired or invalid: run `umami auth login` or set UMAMI_TOKEN"
+	case e.StatusCode == http.StatusForbidden:
+		return "your user does not have permission for this operation"
+	case e.StatusCode == http.StatusNotFound:
+		switch resource := e.resource(); resource {
+		case "website", "team", "user":
+			return fmt.Sprintf("check the %s ID with `umami %ss list`", resource, resource)
+		case "":
+		default:
+			return fmt.Sprintf("check the %s ID", resource)
+		}
+		return "check the endpoint URL; it should point at your Umami server"
+	case e.StatusCode == http.StatusTooManyRequests:
+		return "rate limited by the server: wait and retry, or raise --retries"
+	case e.StatusCode >= 500:
+		return "the Umami server failed to handle the request; check its logs"
+	}
+	return ""
+}
+
+func (e *APIError) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+func (e *APIError) IsServerError() bool {
+	return e.StatusCode >= 500
+}
+
+// resource names the entity addressed by the request URL, such as
+// "website" for /api/websites/:id.
+func (e *APIError) resource() string {
+	u, err := url.Parse(e.URL)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := len(parts) - 2; i >= 0; i-- {
+		switch parts[i] {
+		case "websites", "teams", "users", "reports", "sessions":
+			return strings.TrimSuffix(parts[i], "s")
+		}
+	}
+	return ""
+}
+
+// newAPIError builds an APIError from a failed response body. Umami sends
+// either {"error": {"message", "code"}}, {"error": "..."}, or plain text.
+func newAPIError(method, rawURL string, status int, body []byte) *APIError {
+	e := &APIError{StatusCode: status, Method: method, URL: rawURL}
+
+	var payload struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+		Code    string          `json:"code"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		var detail struct {
+			Message string `json:"message"`
+			Code    string `json:"code"`
+		}
+		var text string
+		switch {
+		case json.Unmarshal(payload.Error, &detail) == nil && detail.Message != "":
+			e.Message, e.Code = detail.Message, detail.Code
+		case json.Unmarshal(payload.Error, &text) == nil && text != "":
+			e.Message, e.Code = text, payload.Code
+		default:
+			e.Message, e.Code = payload.Message, payload.Code
+		}
+	}
+	if e.Message == "" {
+		e.Message = strings.TrimSpace(string(body))
+	}
+	return e
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// Page is one page of a paginated Umami list response.
+type Page[T any] struct {
+	Data     []T `json:"data"`
+	Count    int `json:"count"`
+	Page     int `json:"page"`
+	PageSize int `json:"pageSize"`
+}
+
+// Pager walks a paginated list endpoint one page at a time:
+//
+//	pager := client.NewPager[Website](api, "/websites", nil)
+//	for pager.Next(ctx) {
+//		items = append(items, pager.Page().Data...)
+//	}
+//	if err := pager.Err(); err != nil {
+//		return err
+//	}
+type Pager[T any] struct {
+	client *Client
+	path   string
+	query  url.Values
+	next   int
+	done   bool
+	page   *Page[T]
+	last   []byte
+	err    error
+}
+
+// NewPager starts at the page given in query (default 1) and keeps any
+// other parameters, such as pageSize and search, on every request.
+func NewPager[T any](c *Client, p string, query url.Values) *Pager[T] {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = append([]string(nil), v...)
+	}
+	next := 1
+	if n, err := strconv.Atoi(q.Get("page")); err == nil && n > 0 {
+		next = n
+	}
+	return &Pager[T]{client: c, path: p, query: q, next: next}
+}
+
+// Next fetches the next page and reports whether it holds any data.
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+
+	p.query.Set("page", strconv.Itoa(p.next))
+	page := &Page[T]{}
+	if _, err := p.client.Do(ctx, "GET", p.path+"?"+p.query.Encode(), nil, page, true); err != nil {
+		p.err = err
+		return false
+	}
+	if len(page.Data) == 0 {
+		p.done = true
+		return false
+	}
+	// A server that ignores the page parameter returns the same page
+	// again; stop instead of looping forever.
+	data, err := json.Marshal(page.Data)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	if p.last != nil && bytes.Equal(data, p.last) {
+		p.done = true
+		return false
+	}
+
+	p.page = page
+	p.last = data
+	p.next++
+
+	pageSize := page.PageSize
+	if pageSize == 0 {
+		pageSize, _ = strconv.Atoi(p.query.Get("pageSize"))
+	}
+	switch {
+	case page.Count > 0 && (p.next-1)*pageSize >= page.Count:
+		p.done = true
+	case pageSize == 0 || len(page.Data) < pageSize:
+		// Without a page size there is no way to tell whether the server
+		// honoured the page parameter, so stop rather than loop.
+		p.done = true
+	}
+	return true
+}
+
+func (p *Pager[T]) Page() *Page[T] {
+	return p.page
+}
+
+func (p *Pager[T]) Err() error {
+	return p.err
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+type item struct {
+	ID int `json:"id"`
+}
+
+func collect(t *testing.T, handler http.HandlerFunc, query url.Values) ([]item, int) {
+	t.Helper()
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 50 {
+			t.Error("pager did not stop")
+			writePage(w, Page[item]{})
+			return
+		}
+		handler(w, r)
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var items []item
+	pager := NewPager[item](c, "/items", query)
+	for pager.Next(context.Background()) {
+		items = append(items, pager.Page().Data...)
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return items, requests
+}
+
+func writePage(w http.ResponseWriter, page Page[item]) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func TestPagerFollowsPages(t *testing.T) {
+	items, requests := collect(t, func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page := Page[item]{Count: 5, Page: n, PageSize: 2}
+		for id := (n-1)*2 + 1; id <= min(n*2, 5); id++ {
+			page.Data = append(page.Data, item{ID: id})
+		}
+		writePage(w, page)
+	}, url.Values{"pageSize": {"2"}})
+
+	if len(items) != 5 || items[4].ID != 5 {
+		t.Errorf("got items %v, want ids 1-5", items)
+	}
+	if requests != 3 {
+		t.Errorf("got %d requests, want 3", requests)
+	}
+}
+
+func TestPagerStopsOnShortPageWithoutCount(t *testing.T) {
+	items, requests := collect(t, func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page := Page[item]{PageSize: 2, Data: []item{{ID: n * 10}, {ID: n*10 + 1}}}
+		if n == 2 {
+			page.Data = page.Data[:1]
+		}
+		writePage(w, page)
+	}, url.Values{"pageSize": {"2"}})
+
+	if len(items) != 3 || requests != 2 {
+		t.Errorf("got %d items in %d requests, want 3 in 2", len(items), requests)
+	}
+}
+
+func TestPagerStopsWhenServerIgnoresPage(t *testing.T) {
+	items, requests := collect(t, func(w http.ResponseWriter, r *http.Request) {
+		writePage(w, Page[item]{PageSize: 2, Data: []item{{ID: 1}, {ID: 2}}})
+	}, url.Values{"pageSize": {"2"}})
+
+	if len(items) != 2 {
+		t.Errorf("got %d items, want the first page only", len(items))
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, want 2", requests)
+	}
+}
+
+func TestPagerStartsAtRequestedPage(t *testing.T) {
+	var pages []string
+	collect(t, func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.Query().Get("page"))
+		writePage(w, Page[item]{Count: 6, PageSize: 2, Data: []item{{ID: len(pages)}, {ID: -len(pages)}}})
+	}, url.Values{"page": {"2"}, "pageSize": {"2"}})
+
+	if len(pages) != 2 || pages[0] != "2" || pages[1] != "3" {
+		t.Errorf("requested pages %v, want [2 3]", pages)
+	}
+}
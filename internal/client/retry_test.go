@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+// statusServer answers each request with the next status in statuses,
+// repeating the last one, and counts the requests it received.
+func statusServer(t *testing.T, header http.Header, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		status := statuses[min(n, len(statuses))-1]
+		if status != http.StatusOK {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server, policy RetryPolicy) *Client {
+	t.Helper()
+	c, err := New(srv.URL, "token", WithRetry(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRetryServerErrorThenSuccess(t *testing.T) {
+	srv, requests := statusServer(t, nil, http.StatusServiceUnavailable, http.StatusOK)
+	c := newTestClient(t, srv, fastRetry)
+
+	var out struct{ OK bool }
+	status, err := c.Do(context.Background(), http.MethodGet, "/websites", nil, &out, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != http.StatusOK || !out.OK {
+		t.Errorf("got status %d, ok=%t; want 200, ok=true", status, out.OK)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	srv, requests := statusServer(t, http.Header{"Retry-After": {"1"}}, http.StatusTooManyRequests, http.StatusOK)
+	c := newTestClient(t, srv, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second})
+
+	start := time.Now()
+	if _, err := c.Do(context.Background(), http.MethodGet, "/websites", nil, nil, true); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least 1s", elapsed)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+}
+
+func TestRetryStopsWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+	srv, requests := statusServer(t, http.Header{"Retry-After": {"60"}}, http.StatusTooManyRequests)
+	c := newTestClient(t, srv, fastRetry)
+
+	_, err := c.Do(context.Background(), http.MethodGet, "/websites", nil, nil, true)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("got error %v, want a 429 APIError", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestRetrySkipsPost(t *testing.T) {
+	srv, requests := statusServer(t, nil, http.StatusServiceUnavailable)
+	c := newTestClient(t, srv, fastRetry)
+
+	_, err := c.Do(context.Background(), http.MethodPost, "/websites", map[string]string{"name": "x"}, nil, true)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("got error %v, want a 503 APIError", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	for _, attempts := range []int{0, 1, 2, 4} {
+		srv, requests := statusServer(t, nil, http.StatusBadGateway)
+		policy := fastRetry
+		policy.MaxAttempts = attempts
+		c := newTestClient(t, srv, policy)
+
+		if _, err := c.Do(context.Background(), http.MethodGet, "/websites", nil, nil, true); err == nil {
+			t.Errorf("MaxAttempts %d: got no error, want 502", attempts)
+		}
+		want := int32(max(attempts, 1))
+		if got := requests.Load(); got != want {
+			t.Errorf("MaxAttempts %d: got %d requests, want %d", attempts, got, want)
+		}
+	}
+}
+
+func TestRetryNotOnClientError(t *testing.T) {
+	srv, requests := statusServer(t, nil, http.StatusNotFound)
+	c := newTestClient(t, srv, fastRetry)
+
+	if _, err := c.Do(context.Background(), http.MethodGet, "/websites/x", nil, nil, true); err == nil {
+		t.Fatal("got no error, want 404")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestRetryCancelStopsBackoff(t *testing.T) {
+	srv, requests := statusServer(t, http.Header{"Retry-After": {"60"}}, http.StatusServiceUnavailable)
+	c := newTestClient(t, srv, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := c.Do(ctx, http.MethodGet, "/websites", nil, nil, true)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancel took %s to stop the backoff", elapsed)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestWithTimeoutCopiesHTTPClient(t *testing.T) {
+	shared := &http.Client{}
+	for _, opts := range [][]Option{
+		{WithHTTPClient(shared), WithTimeout(5 * time.Second)},
+		{WithTimeout(5 * time.Second), WithHTTPClient(shared)},
+	} {
+		c, err := New("http://example.com", "token", opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.httpClient.Timeout != 5*time.Second {
+			t.Errorf("got timeout %s, want 5s", c.httpClient.Timeout)
+		}
+	}
+	if shared.Timeout != 0 {
+		t.Errorf("shared client timeout changed to %s", shared.Timeout)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	withRetryAfter := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {value}}}
+	}
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		retry    int
+		resp     *http.Response
+		min, max time.Duration
+		ok       bool
+	}{
+		{"first retry", RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}, 1, nil, 50 * time.Millisecond, 100 * time.Millisecond, true},
+		{"second retry", RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}, 2, nil, 100 * time.Millisecond, 200 * time.Millisecond, true},
+		{"capped", RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}, 6, nil, 500 * time.Millisecond, time.Second, true},
+		{"no cap", RetryPolicy{BaseDelay: 100 * time.Millisecond}, 6, nil, 1600 * time.Millisecond, 3200 * time.Millisecond, true},
+		{"no cap overflow", RetryPolicy{BaseDelay: time.Second}, 100, nil, time.Duration(1 << 62), time.Duration(1<<63 - 1), true},
+		{"zero policy", RetryPolicy{}, 3, nil, 0, 0, true},
+		{"retry-after", RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second}, 1, withRetryAfter("2"), 2 * time.Second, 2 * time.Second, true},
+		{"retry-after without cap", RetryPolicy{}, 1, withRetryAfter("120"), 2 * time.Minute, 2 * time.Minute, true},
+		{"retry-after beyond cap", RetryPolicy{MaxDelay: time.Second}, 1, withRetryAfter("2"), 0, 0, false},
+		{"invalid retry-after", RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: time.Second}, 1, withRetryAfter("soon"), 5 * time.Millisecond, 10 * time.Millisecond, true},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			d, ok := tt.policy.backoff(tt.retry, tt.resp)
+			if ok != tt.ok {
+				t.Errorf("%s: got ok=%t, want %t", tt.name, ok, tt.ok)
+				break
+			}
+			if d < tt.min || d > tt.max {
+				t.Errorf("%s: got %s, want between %s and %s", tt.name, d, tt.min, tt.max)
+				break
+			}
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	future := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	tests := []struct {
+		value    string
+		min, max time.Duration
+		ok       bool
+	}{
+		{"", 0, 0, false},
+		{"0", 0, 0, true},
+		{"3", 3 * time.Second, 3 * time.Second, true},
+		{"-1", 0, 0, false},
+		{"1.5", 0, 0, false},
+		{"soon", 0, 0, false},
+		{future, 28 * time.Second, 30 * time.Second, true},
+		{past, 0, 0, true},
+	}
+	for _, tt := range tests {
+		d, ok := retryAfter(tt.value)
+		if ok != tt.ok {
+			t.Errorf("retryAfter(%q): got ok=%t, want %t", tt.value, ok, tt.ok)
+			continue
+		}
+		if d < tt.min || d > tt.max {
+			t.Errorf("retryAfter(%q) = %s, want between %s and %s", tt.value, d, tt.min, tt.max)
+		}
+	}
+}
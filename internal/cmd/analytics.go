@@ -1,31 +1,35 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"net/url"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
-	"github.com/yborunov/umami-cli/internal/client"
 	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
 )
 
 type AnalyticsCmd struct {
 	Active          AnalyticsActiveCmd          `cmd:"" help:"Active users"`
+	Compare         AnalyticsCompareCmd         `cmd:"" help:"Compare stats and metrics with another period"`
 	EventsSeries    AnalyticsEventsSeriesCmd    `cmd:"" help:"Event series"`
 	Metrics         AnalyticsMetricsCmd         `cmd:"" help:"Metrics"`
 	MetricsExpanded AnalyticsMetricsExpandedCmd `cmd:"" help:"Expanded metrics"`
 	Pageviews       AnalyticsPageviewsCmd       `cmd:"" help:"Pageviews"`
+	Realtime        AnalyticsRealtimeCmd        `cmd:"" help:"Live view of the last 30 minutes"`
 	Stats           AnalyticsStatsCmd           `cmd:"" help:"Summary stats"`
 }
 
-type TimeRange struct {
-	StartAt int64 `help:"Start timestamp (ms since epoch)"`
-	EndAt   int64 `help:"End timestamp (ms since epoch)"`
-}
-
 type Filters struct {
 	Path       string `help:"Filter by URL path"`
 	Referrer   string `help:"Filter by referrer"`
@@ -49,29 +53,197 @@ type AnalyticsActiveCmd struct {
 }
 
 func (c *AnalyticsActiveCmd) Run(ctx *Context) error {
-	if c.WebsiteID == "" {
-		return errors.New("website-id is required")
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Analytics.Active(context.Background(), c.WebsiteID)
+	if err != nil {
+		return err
+	}
+	t := out.NewTable("Visitors")
+	t.Row(resp.Visitors)
+	return ctx.Print(resp, t)
+}
+
+type AnalyticsCompareCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Compare      string `help:"Period to compare with (prev|yoy)" enum:"prev,yoy" default:"prev"`
+	CompareStart string `help:"Start of an explicit comparison period (overrides --compare)"`
+	CompareEnd   string `help:"End of an explicit comparison period (overrides --compare)"`
+	Type         string `help:"Also compare the top values of this metric type (e.g. path, referrer, country)"`
+	Limit        int    `help:"Number of metric values compared with --type" default:"10"`
+	Filters
+}
+
+// comparison is one compared value. ChangePct is nil when the previous
+// value is zero.
+type comparison struct {
+	Name      string   `json:"name"`
+	Current   int64    `json:"current"`
+	Previous  int64    `json:"previous"`
+	Change    int64    `json:"change"`
+	ChangePct *float64 `json:"changePct"`
+	// lowerIsBetter flips the arrow colors, e.g. for bounces.
+	lowerIsBetter bool
+}
+
+type periodComparison struct {
+	Current  period       `json:"current"`
+	Previous period       `json:"previous"`
+	Stats    []comparison `json:"stats"`
+	Metrics  []comparison `json:"metrics,omitempty"`
+}
+
+type period struct {
+	StartAt time.Time `json:"startAt"`
+	EndAt   time.Time `json:"endAt"`
+}
+
+func (c *AnalyticsCompareCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+	startAt, endAt, err := c.TimeRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+	prevStart, prevEnd, err := c.TimeRange.comparison(startAt, endAt, c.Compare, c.CompareStart, c.CompareEnd)
+	if err != nil {
+		return err
 	}
 
-	api, err := client.New(ctx.Config.Endpoint, ctx.Config.Token)
+	api, err := ctx.API()
 	if err != nil {
 		return err
 	}
 
-	var resp any
-	path := fmt.Sprintf("/websites/%s/active", c.WebsiteID)
-	_, err = api.Do(context.Background(), "GET", path, nil, &resp, true)
+	bg := context.Background()
+	current, err := api.Analytics.Stats(bg, c.WebsiteID, buildQuery(startAt, endAt, "", c.Timezone, c.Filters, 0, 0, ""))
 	if err != nil {
 		return err
 	}
-	return out.PrintJSON(resp)
+	previous, err := api.Analytics.Stats(bg, c.WebsiteID, buildQuery(prevStart, prevEnd, "", c.Timezone, c.Filters, 0, 0, ""))
+	if err != nil {
+		return err
+	}
+
+	result := periodComparison{
+		Current:  period{time.UnixMilli(startAt), time.UnixMilli(endAt)},
+		Previous: period{time.UnixMilli(prevStart), time.UnixMilli(prevEnd)},
+		Stats: []comparison{
+			compareValues("pageviews", current.Pageviews, previous.Pageviews),
+			compareValues("visitors", current.Visitors, previous.Visitors),
+			compareValues("visits", current.Visits, previous.Visits),
+			compareValues("bounces", current.Bounces, previous.Bounces),
+			compareValues("totaltime", current.TotalTime, previous.TotalTime),
+		},
+	}
+	result.Stats[3].lowerIsBetter = true
+
+	if c.Type != "" {
+		cur, err := api.Analytics.Metrics(bg, c.WebsiteID, buildQuery(startAt, endAt, "", c.Timezone, c.Filters, c.Limit, 0, c.Type))
+		if err != nil {
+			return err
+		}
+		prev, err := api.Analytics.Metrics(bg, c.WebsiteID, buildQuery(prevStart, prevEnd, "", c.Timezone, c.Filters, c.Limit, 0, c.Type))
+		if err != nil {
+			return err
+		}
+		result.Metrics = compareMetrics(cur, prev)
+	}
+
+	t := out.NewTable("Metric", "Current", "Previous", "Change", "Change %")
+	for _, row := range result.Stats {
+		t.Row(row.Name, row.Current, row.Previous, signed(row.Change), c.trend(ctx, row))
+	}
+	for _, row := range result.Metrics {
+		t.Row(c.Type+": "+row.Name, row.Current, row.Previous, signed(row.Change), c.trend(ctx, row))
+	}
+	if ctx.Output == out.FormatTable {
+		out.Printf("%s – %s vs %s – %s\n\n", formatDate(result.Current.StartAt), formatDate(result.Current.EndAt), formatDate(result.Previous.StartAt), formatDate(result.Previous.EndAt))
+	}
+	return ctx.Print(result, t)
+}
+
+// trend renders the percentage change. Tables get a colored arrow; other
+// tabular formats a signed number.
+func (c *AnalyticsCompareCmd) trend(ctx *Context, row comparison) string {
+	if row.ChangePct == nil {
+		if row.Current > 0 {
+			return "new"
+		}
+		return "-"
+	}
+	pct := *row.ChangePct
+	if ctx.Output != out.FormatTable {
+		return fmt.Sprintf("%+.1f%%", pct)
+	}
+
+	switch {
+	case pct > 0:
+		color := out.Green
+		if row.lowerIsBetter {
+			color = out.Red
+		}
+		return out.Colorize(fmt.Sprintf("▲ %.1f%%", pct), color)
+	case pct < 0:
+		color := out.Red
+		if row.lowerIsBetter {
+			color = out.Green
+		}
+		return out.Colorize(fmt.Sprintf("▼ %.1f%%", -pct), color)
+	}
+	return out.Colorize("= 0.0%", out.Gray)
+}
+
+func compareValues(name string, current, previous int64) comparison {
+	row := comparison{Name: name, Current: current, Previous: previous, Change: current - previous}
+	if previous != 0 {
+		pct := float64(current-previous) * 100 / float64(previous)
+		row.ChangePct = &pct
+	}
+	return row
+}
+
+// compareMetrics pairs metric values by name, keeping the current period's
+// order and appending values only seen in the comparison period.
+func compareMetrics(current, previous []umami.Metric) []comparison {
+	prev := map[string]int64{}
+	for _, m := range previous {
+		prev[m.X] = m.Y
+	}
+	seen := map[string]bool{}
+	rows := make([]comparison, 0, len(current))
+	for _, m := range current {
+		seen[m.X] = true
+		rows = append(rows, compareValues(m.X, m.Y, prev[m.X]))
+	}
+	for _, m := range previous {
+		if !seen[m.X] {
+			rows = append(rows, compareValues(m.X, 0, m.Y))
+		}
+	}
+	return rows
+}
+
+func signed(n int64) string {
+	if n > 0 {
+		return "+" + strconv.FormatInt(n, 10)
+	}
+	return strconv.FormatInt(n, 10)
 }
 
 type AnalyticsEventsSeriesCmd struct {
 	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
 	TimeRange
-	Unit     string `help:"Time unit (year|month|day|hour|minute)"`
-	Timezone string `help:"Timezone (e.g. America/Los_Angeles)"`
+	Unit string `help:"Time unit (year|month|day|hour|minute)"`
 	Filters
 }
 
@@ -79,28 +251,32 @@ func (c *AnalyticsEventsSeriesCmd) Run(ctx *Context) error {
 	if err := validateWebsiteID(c.WebsiteID); err != nil {
 		return err
 	}
-	startAt, endAt := normalizeRange(c.StartAt, c.EndAt)
+	startAt, endAt, err := c.TimeRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
 
-	api, err := client.New(ctx.Config.Endpoint, ctx.Config.Token)
+	api, err := ctx.API()
 	if err != nil {
 		return err
 	}
 
 	q := buildQuery(startAt, endAt, c.Unit, c.Timezone, c.Filters, 0, 0, "")
-	path := withQuery(fmt.Sprintf("/websites/%s/events/series", c.WebsiteID), q)
-
-	var resp any
-	_, err = api.Do(context.Background(), "GET", path, nil, &resp, true)
+	resp, err := api.Analytics.EventsSeries(context.Background(), c.WebsiteID, q)
 	if err != nil {
 		return err
 	}
-	return out.PrintJSON(resp)
+	t := out.NewTable("Event", "Time", "Count")
+	for _, p := range resp {
+		t.Row(p.X, p.T, p.Y)
+	}
+	return ctx.Print(resp, t)
 }
 
 type AnalyticsMetricsCmd struct {
 	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
 	TimeRange
-	Type   string `help:"Metric type (path|entry|exit|title|query|referrer|channel|domain|country|region|city|browser|os|device|language|screen|event|hostname|tag|distinctId)"`
+	Type   string `help:"Metric type (${metric_types})"`
 	Limit  int    `help:"Number of rows returned (default 500)"`
 	Offset int    `help:"Number of rows to skip (default 0)"`
 	Filters
@@ -113,28 +289,92 @@ func (c *AnalyticsMetricsCmd) Run(ctx *Context) error {
 	if c.Type == "" {
 		return errors.New("type is required")
 	}
-	startAt, endAt := normalizeRange(c.StartAt, c.EndAt)
-
-	api, err := client.New(ctx.Config.Endpoint, ctx.Config.Token)
+	startAt, endAt, err := c.TimeRange.resolve(time.Now())
 	if err != nil {
 		return err
 	}
 
-	q := buildQuery(startAt, endAt, "", "", c.Filters, c.Limit, c.Offset, c.Type)
-	path := withQuery(fmt.Sprintf("/websites/%s/metrics", c.WebsiteID), q)
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
 
-	var resp any
-	_, err = api.Do(context.Background(), "GET", path, nil, &resp, true)
+	limit := c.Limit
+	if c.Type == "distinctId" && limit == 0 {
+		limit = distinctIDLimit
+	}
+	q := buildQuery(startAt, endAt, "", c.Timezone, c.Filters, limit, c.Offset, c.Type)
+	resp, err := api.Analytics.Metrics(context.Background(), c.WebsiteID, q)
 	if err != nil {
 		return err
 	}
-	return out.PrintJSON(resp)
+	if c.Type == "distinctId" {
+		return c.printDistinctIDs(ctx, api, resp, startAt, endAt)
+	}
+
+	return ctx.Print(resp, metricsTable(c.Type, resp))
+}
+
+func metricsTable(metricType string, metrics []umami.Metric) *out.Table {
+	t := out.NewTable(metricType, "Count")
+	for _, m := range metrics {
+		t.Row(m.X, m.Y)
+	}
+	return t
+}
+
+// metricTypes lists the values accepted by --type on the metrics commands.
+// Help strings refer to it as ${metric_types}.
+var metricTypes = []string{
+	"path", "entry", "exit", "title", "query", "referrer", "channel", "domain",
+	"country", "region", "city", "browser", "os", "device", "language", "screen",
+	"event", "hostname", "tag", "distinctId",
+}
+
+func validateMetricType(metricType string) error {
+	if !slices.Contains(metricTypes, metricType) {
+		return fmt.Errorf("unknown metric type %q (%s)", metricType, strings.Join(metricTypes, "|"))
+	}
+	return nil
+}
+
+// distinctIDLimit caps the distinctId drill-down when --limit is unset,
+// since it makes one sessions request per user.
+const distinctIDLimit = 50
+
+type distinctIDMetric struct {
+	X        string `json:"x"`
+	Y        int64  `json:"y"`
+	Sessions int    `json:"sessions"`
+}
+
+// printDistinctIDs adds each identified user's session count in the range,
+// taken from the sessions list filtered by distinct ID.
+func (c *AnalyticsMetricsCmd) printDistinctIDs(ctx *Context, api *umami.Client, metrics []umami.Metric, startAt, endAt int64) error {
+	rows := make([]distinctIDMetric, 0, len(metrics))
+	t := out.NewTable("Distinct ID", "Count", "Sessions")
+	for _, m := range metrics {
+		filters := c.Filters
+		filters.DistinctID = m.X
+		q := buildQuery(startAt, endAt, "", c.Timezone, filters, 0, 0, "").Values()
+		res, err := api.Sessions.List(context.Background(), c.WebsiteID, q, umami.ListParams{PageSize: 1})
+		if err != nil {
+			return err
+		}
+		rows = append(rows, distinctIDMetric{X: m.X, Y: m.Y, Sessions: res.Count})
+		t.Row(m.X, m.Y, res.Count)
+	}
+	if len(rows) == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No identified users found.\n")
+		return nil
+	}
+	return ctx.Print(rows, t)
 }
 
 type AnalyticsMetricsExpandedCmd struct {
 	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
 	TimeRange
-	Type   string `help:"Metric type (path|entry|exit|title|query|referrer|channel|domain|country|region|city|browser|os|device|language|screen|event|hostname|tag|distinctId)"`
+	Type   string `help:"Metric type (${metric_types})"`
 	Limit  int    `help:"Number of rows returned (default 500)"`
 	Offset int    `help:"Number of rows to skip (default 0)"`
 	Filters
@@ -147,30 +387,33 @@ func (c *AnalyticsMetricsExpandedCmd) Run(ctx *Context) error {
 	if c.Type == "" {
 		return errors.New("type is required")
 	}
-	startAt, endAt := normalizeRange(c.StartAt, c.EndAt)
-
-	api, err := client.New(ctx.Config.Endpoint, ctx.Config.Token)
+	startAt, endAt, err := c.TimeRange.resolve(time.Now())
 	if err != nil {
 		return err
 	}
 
-	q := buildQuery(startAt, endAt, "", "", c.Filters, c.Limit, c.Offset, c.Type)
-	path := withQuery(fmt.Sprintf("/websites/%s/metrics/expanded", c.WebsiteID), q)
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
 
-	var resp any
-	_, err = api.Do(context.Background(), "GET", path, nil, &resp, true)
+	q := buildQuery(startAt, endAt, "", c.Timezone, c.Filters, c.Limit, c.Offset, c.Type)
+	resp, err := api.Analytics.MetricsExpanded(context.Background(), c.WebsiteID, q)
 	if err != nil {
 		return err
 	}
-	return out.PrintJSON(resp)
+	t := out.NewTable(c.Type, "Pageviews", "Visitors", "Visits", "Bounces", "Total time")
+	for _, m := range resp {
+		t.Row(m.Name, m.Pageviews, m.Visitors, m.Visits, m.Bounces, m.TotalTime)
+	}
+	return ctx.Print(resp, t)
 }
 
 type AnalyticsPageviewsCmd struct {
 	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
 	TimeRange
-	Unit     string `help:"Time unit (year|month|day|hour|minute)"`
-	Timezone string `help:"Timezone (e.g. America/Los_Angeles)"`
-	Compare  string `help:"Comparison value (prev|yoy)"`
+	Unit    string `help:"Time unit (year|month|day|hour|minute)"`
+	Compare string `help:"Comparison value (prev|yoy)"`
 	Filters
 }
 
@@ -178,25 +421,172 @@ func (c *AnalyticsPageviewsCmd) Run(ctx *Context) error {
 	if err := validateWebsiteID(c.WebsiteID); err != nil {
 		return err
 	}
-	startAt, endAt := normalizeRange(c.StartAt, c.EndAt)
+	startAt, endAt, err := c.TimeRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
 
-	api, err := client.New(ctx.Config.Endpoint, ctx.Config.Token)
+	api, err := ctx.API()
 	if err != nil {
 		return err
 	}
 
 	q := buildQuery(startAt, endAt, c.Unit, c.Timezone, c.Filters, 0, 0, "")
-	if c.Compare != "" {
-		q.Set("compare", c.Compare)
+	q.Compare = c.Compare
+	resp, err := api.Analytics.Pageviews(context.Background(), c.WebsiteID, q)
+	if err != nil {
+		return err
 	}
-	path := withQuery(fmt.Sprintf("/websites/%s/pageviews", c.WebsiteID), q)
+	return ctx.Print(resp, pageviewsTable(resp))
+}
 
-	var resp any
-	_, err = api.Do(context.Background(), "GET", path, nil, &resp, true)
+type AnalyticsRealtimeCmd struct {
+	WebsiteID string        `arg:"" name:"website-id" help:"Website ID"`
+	Watch     bool          `short:"w" help:"Keep polling and redraw until interrupted"`
+	Interval  time.Duration `help:"Polling interval with --watch" default:"5s"`
+	Top       int           `help:"Rows shown per section" default:"10"`
+}
+
+func (c *AnalyticsRealtimeCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+	if c.Watch && c.Interval < time.Second {
+		return errors.New("interval must be at least 1s")
+	}
+
+	api, err := ctx.API()
 	if err != nil {
 		return err
 	}
-	return out.PrintJSON(resp)
+
+	if !c.Watch {
+		rt, err := api.Analytics.Realtime(context.Background(), c.WebsiteID, umami.QueryParams{})
+		if err != nil {
+			return err
+		}
+		if ctx.Output == out.FormatTable {
+			return c.render(os.Stdout, rt, time.Now())
+		}
+		return ctx.Print(rt, c.table(rt))
+	}
+
+	// Ctrl-C cancels the context, which aborts any request in flight and
+	// ends the loop without an error.
+	watchCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		rt, err := api.Analytics.Realtime(watchCtx, c.WebsiteID, umami.QueryParams{})
+		if watchCtx.Err() != nil {
+			return nil
+		}
+		if err := c.draw(ctx, rt, err); err != nil {
+			return err
+		}
+
+		select {
+		case <-watchCtx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// draw shows one watch iteration. Tables redraw the screen in place and
+// keep polling through request errors; other formats append a snapshot.
+func (c *AnalyticsRealtimeCmd) draw(ctx *Context, rt *umami.Realtime, fetchErr error) error {
+	if ctx.Output != out.FormatTable {
+		if fetchErr != nil {
+			return fetchErr
+		}
+		return ctx.Print(rt, c.table(rt))
+	}
+
+	var b bytes.Buffer
+	b.WriteString("\033[H\033[2J")
+	if fetchErr != nil {
+		c.header(&b, time.Now())
+		fmt.Fprintf(&b, "error: %v\n", fetchErr)
+	} else if err := c.render(&b, rt, time.Now()); err != nil {
+		return err
+	}
+	_, err := os.Stdout.Write(b.Bytes())
+	return err
+}
+
+func (c *AnalyticsRealtimeCmd) render(w io.Writer, rt *umami.Realtime, now time.Time) error {
+	c.header(w, now)
+	fmt.Fprintf(w, "Visitors: %d   Views: %d   Events: %d   Countries: %d\n", rt.Totals.Visitors, rt.Totals.Views, rt.Totals.Events, rt.Totals.Countries)
+
+	sections := []struct {
+		header string
+		counts map[string]int64
+	}{
+		{"Page", rt.URLs},
+		{"Referrer", rt.Referrers},
+		{"Country", rt.Countries},
+	}
+	for _, sec := range sections {
+		fmt.Fprintln(w)
+		t := out.NewTable(sec.header, "Views")
+		for _, v := range topCounts(sec.counts, c.Top) {
+			t.Row(v.Name, v.Value)
+		}
+		if err := out.Fprint(w, out.FormatTable, nil, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *AnalyticsRealtimeCmd) header(w io.Writer, now time.Time) {
+	status := "last 30 minutes"
+	if c.Watch {
+		status = fmt.Sprintf("every %s, Ctrl-C to quit", c.Interval)
+	}
+	fmt.Fprintf(w, "Website %s  (updated %s, %s)\n\n", c.WebsiteID, now.Format("15:04:05"), status)
+}
+
+// table flattens the snapshot for csv, tsv and markdown output.
+func (c *AnalyticsRealtimeCmd) table(rt *umami.Realtime) *out.Table {
+	t := out.NewTable("Section", "Value", "Views")
+	t.Row("totals", "visitors", rt.Totals.Visitors)
+	t.Row("totals", "views", rt.Totals.Views)
+	t.Row("totals", "events", rt.Totals.Events)
+	for _, sec := range []struct {
+		name   string
+		counts map[string]int64
+	}{
+		{"page", rt.URLs},
+		{"referrer", rt.Referrers},
+		{"country", rt.Countries},
+	} {
+		for _, v := range topCounts(sec.counts, c.Top) {
+			t.Row(sec.name, v.Name, v.Value)
+		}
+	}
+	return t
+}
+
+// topCounts returns the n largest counts, ties broken by name.
+func topCounts(counts map[string]int64, n int) []umami.NamedValue {
+	values := make([]umami.NamedValue, 0, len(counts))
+	for name, count := range counts {
+		values = append(values, umami.NamedValue{Name: name, Value: count})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Value != values[j].Value {
+			return values[i].Value > values[j].Value
+		}
+		return values[i].Name < values[j].Name
+	})
+	if n > 0 && len(values) > n {
+		values = values[:n]
+	}
+	return values
 }
 
 type AnalyticsStatsCmd struct {
@@ -209,22 +599,80 @@ func (c *AnalyticsStatsCmd) Run(ctx *Context) error {
 	if err := validateWebsiteID(c.WebsiteID); err != nil {
 		return err
 	}
-	startAt, endAt := normalizeRange(c.StartAt, c.EndAt)
-
-	api, err := client.New(ctx.Config.Endpoint, ctx.Config.Token)
+	startAt, endAt, err := c.TimeRange.resolve(time.Now())
 	if err != nil {
 		return err
 	}
 
-	q := buildQuery(startAt, endAt, "", "", c.Filters, 0, 0, "")
-	path := withQuery(fmt.Sprintf("/websites/%s/stats", c.WebsiteID), q)
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
 
-	var resp any
-	_, err = api.Do(context.Background(), "GET", path, nil, &resp, true)
+	q := buildQuery(startAt, endAt, "", c.Timezone, c.Filters, 0, 0, "")
+	resp, err := api.Analytics.Stats(context.Background(), c.WebsiteID, q)
 	if err != nil {
 		return err
 	}
-	return out.PrintJSON(resp)
+	return ctx.Print(resp, statsTable(resp))
+}
+
+func statsTable(stats *umami.Stats) *out.Table {
+	rows := []struct {
+		name    string
+		current int64
+		prev    func(*umami.StatsValues) int64
+	}{
+		{"pageviews", stats.Pageviews, func(v *umami.StatsValues) int64 { return v.Pageviews }},
+		{"visitors", stats.Visitors, func(v *umami.StatsValues) int64 { return v.Visitors }},
+		{"visits", stats.Visits, func(v *umami.StatsValues) int64 { return v.Visits }},
+		{"bounces", stats.Bounces, func(v *umami.StatsValues) int64 { return v.Bounces }},
+		{"totaltime", stats.TotalTime, func(v *umami.StatsValues) int64 { return v.TotalTime }},
+	}
+
+	if stats.Comparison == nil {
+		t := out.NewTable("Metric", "Value")
+		for _, r := range rows {
+			t.Row(r.name, r.current)
+		}
+		return t
+	}
+
+	t := out.NewTable("Metric", "Value", "Previous")
+	for _, r := range rows {
+		t.Row(r.name, r.current, r.prev(stats.Comparison))
+	}
+	return t
+}
+
+// pageviewsTable lines up pageviews and sessions by bucket. Comparison
+// series are matched by position since their buckets cover another period.
+func pageviewsTable(pv *umami.Pageviews) *out.Table {
+	sessions := map[string]int64{}
+	for _, p := range pv.Sessions {
+		sessions[p.X] = p.Y
+	}
+
+	if pv.Compare == nil {
+		t := out.NewTable("Time", "Pageviews", "Sessions")
+		for _, p := range pv.Pageviews {
+			t.Row(p.X, p.Y, sessions[p.X])
+		}
+		return t
+	}
+
+	t := out.NewTable("Time", "Pageviews", "Sessions", "Previous pageviews", "Previous sessions")
+	for i, p := range pv.Pageviews {
+		var prevPageviews, prevSessions int64
+		if i < len(pv.Compare.Pageviews) {
+			prevPageviews = pv.Compare.Pageviews[i].Y
+		}
+		if i < len(pv.Compare.Sessions) {
+			prevSessions = pv.Compare.Sessions[i].Y
+		}
+		t.Row(p.X, p.Y, sessions[p.X], prevPageviews, prevSessions)
+	}
+	return t
 }
 
 func validateWebsiteID(websiteID string) error {
@@ -234,68 +682,52 @@ func validateWebsiteID(websiteID string) error {
 	return nil
 }
 
-func normalizeRange(startAt, endAt int64) (int64, int64) {
-	if startAt != 0 && endAt != 0 {
-		return startAt, endAt
+func buildQuery(startAt, endAt int64, unit, timezone string, filters Filters, limit, offset int, metricType string) umami.QueryParams {
+	q := umami.QueryParams{
+		Unit:     unit,
+		Timezone: timezone,
+		Type:     metricType,
+		Limit:    limit,
+		Offset:   offset,
+		Filters:  filters.values(),
 	}
-	now := time.Now().UTC()
-	end := now.UnixMilli()
-	start := now.Add(-24 * time.Hour).UnixMilli()
-	return start, end
-}
-
-func buildQuery(startAt, endAt int64, unit, timezone string, filters Filters, limit, offset int, metricType string) url.Values {
-	q := url.Values{}
 	if startAt != 0 {
-		q.Set("startAt", strconv.FormatInt(startAt, 10))
+		q.StartAt = time.UnixMilli(startAt)
 	}
 	if endAt != 0 {
-		q.Set("endAt", strconv.FormatInt(endAt, 10))
+		q.EndAt = time.UnixMilli(endAt)
 	}
-	if unit != "" {
-		q.Set("unit", unit)
-	}
-	if timezone != "" {
-		q.Set("timezone", timezone)
-	}
-	if metricType != "" {
-		q.Set("type", metricType)
-	}
-	if limit > 0 {
-		q.Set("limit", strconv.Itoa(limit))
-	}
-	if offset > 0 {
-		q.Set("offset", strconv.Itoa(offset))
+	if q.Timezone == "" {
+		q.Timezone = localTimezone()
 	}
+	return q
+}
 
-	addFilter := func(key, value string) {
+// values maps the set filters to their Umami parameter names. Analytics
+// endpoints take them as query parameters and reports as a filters object.
+func (f Filters) values() map[string]string {
+	m := map[string]string{}
+	add := func(key, value string) {
 		if value != "" {
-			q.Set(key, value)
+			m[key] = value
 		}
 	}
 
-	addFilter("path", filters.Path)
-	addFilter("referrer", filters.Referrer)
-	addFilter("title", filters.Title)
-	addFilter("query", filters.Query)
-	addFilter("browser", filters.Browser)
-	addFilter("os", filters.OS)
-	addFilter("device", filters.Device)
-	addFilter("country", filters.Country)
-	addFilter("region", filters.Region)
-	addFilter("city", filters.City)
-	addFilter("hostname", filters.Hostname)
-	addFilter("tag", filters.Tag)
-	addFilter("distinctId", filters.DistinctID)
-	addFilter("segment", filters.Segment)
-	addFilter("cohort", filters.Cohort)
-
-	return q
-}
-
-func withQuery(path string, q url.Values) string {
-	if len(q) == 0 {
-		return path
-	}
-	return path + "?" + q.Encode()
+	add("path", f.Path)
+	add("referrer", f.Referrer)
+	add("title", f.Title)
+	add("query", f.Query)
+	add("browser", f.Browser)
+	add("os", f.OS)
+	add("device", f.Device)
+	add("country", f.Country)
+	add("region", f.Region)
+	add("city", f.City)
+	add("hostname", f.Hostname)
+	add("tag", f.Tag)
+	add("distinctId", f.DistinctID)
+	add("segment", f.Segment)
+	add("cohort", f.Cohort)
+
+	return m
 }
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"time"
 
-	"github.com/yborunov/umami-cli/internal/client"
 	"github.com/yborunov/umami-cli/internal/out"
 )
 
@@ -19,32 +18,17 @@ type AuthLoginCmd struct {
 	Password string `help:"Umami password" env:"UMAMI_PASSWORD"`
 }
 
-type loginResponse struct {
-	Token string `json:"token"`
-	User  struct {
-		ID       string `json:"id"`
-		Username string `json:"username"`
-	} `json:"user"`
-}
-
-type loginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-}
-
 func (c *AuthLoginCmd) Run(ctx *Context) error {
 	if c.Username == "" || c.Password == "" {
 		return errors.New("username and password are required")
 	}
 
-	api, err := client.New(ctx.Config.Endpoint, "")
+	api, err := ctx.API()
 	if err != nil {
 		return err
 	}
 
-	req := loginRequest{Username: c.Username, Password: c.Password}
-	resp := loginResponse{}
-	_, err = api.Do(context.Background(), "POST", "/auth/login", req, &resp, false)
+	resp, err := api.Auth.Login(context.Background(), c.Username, c.Password)
 	if err != nil {
 		return err
 	}
@@ -54,8 +38,8 @@ func (c *AuthLoginCmd) Run(ctx *Context) error {
 		return err
 	}
 
-	if ctx.JSON {
-		return out.PrintJSON(resp)
+	if ctx.Output.Structured() {
+		return ctx.Print(resp, nil)
 	}
 
 	out.Printf("Logged in as %s (id %s). Token saved.\n", resp.User.Username, resp.User.ID)
@@ -64,36 +48,29 @@ func (c *AuthLoginCmd) Run(ctx *Context) error {
 
 type AuthVerifyCmd struct{}
 
-type verifyResponse struct {
-	Valid bool   `json:"valid"`
-	User  string `json:"user"`
-	Role  string `json:"role"`
-}
-
 func (c *AuthVerifyCmd) Run(ctx *Context) error {
-	api, err := client.New(ctx.Config.Endpoint, ctx.Config.Token)
+	api, err := ctx.API()
 	if err != nil {
 		return err
 	}
 
-	var resp any
 	if debugEnabled() {
 		out.Printf("debug: verify request method=POST path=/auth/verify endpoint=%s\n", ctx.Config.Endpoint)
 	}
-	status, err := api.Do(context.Background(), "POST", "/auth/verify", nil, &resp, true)
+	user, err := api.Auth.Verify(context.Background())
 	if debugEnabled() {
 		if err != nil {
-			out.Printf("debug: verify response status=%d error=%v\n", status, err)
+			out.Printf("debug: verify response error=%v\n", err)
 		} else {
-			out.Printf("debug: verify response status=%d\n", status)
+			out.Printf("debug: verify response user=%s\n", user.Username)
 		}
 	}
 	if err != nil {
 		return err
 	}
 
-	if ctx.JSON {
-		return out.PrintJSON(resp)
+	if ctx.Output.Structured() {
+		return ctx.Print(user, nil)
 	}
 
 	out.Printf("Token verified at %s.\n", time.Now().Format(time.RFC3339))
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+type CacheCmd struct {
+	Clear CacheClearCmd `cmd:"" help:"Remove cached responses"`
+	Stats CacheStatsCmd `cmd:"" help:"Show cache location, entries and size"`
+}
+
+type CacheClearCmd struct {
+	Expired bool `help:"Only remove entries older than --cache-ttl"`
+}
+
+func (c *CacheClearCmd) Run(ctx *Context) error {
+	cache, err := umami.NewCache("", ctx.CacheTTL)
+	if err != nil {
+		return err
+	}
+	removed, err := cache.Clear(c.Expired)
+	if err != nil {
+		return err
+	}
+	if ctx.Output.Structured() {
+		return ctx.Print(map[string]any{"dir": cache.Dir, "removed": removed}, nil)
+	}
+	out.Printf("Removed %d cached responses from %s.\n", removed, cache.Dir)
+	return nil
+}
+
+type CacheStatsCmd struct{}
+
+func (c *CacheStatsCmd) Run(ctx *Context) error {
+	cache, err := umami.NewCache("", ctx.CacheTTL)
+	if err != nil {
+		return err
+	}
+	stats, err := cache.Stats()
+	if err != nil {
+		return err
+	}
+	t := out.NewTable("Dir", "Entries", "Expired", "Size", "TTL")
+	t.Row(stats.Dir, stats.Entries, stats.Expired, formatBytes(stats.Bytes), ctx.CacheTTL.String())
+	return ctx.Print(stats, t)
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
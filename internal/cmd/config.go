@@ -0,0 +1,100 @@
+package cmd
+
+import "github.com/yborunov/umami-cli/internal/out"
+
+type ConfigCmd struct {
+	Profiles ConfigProfilesCmd `cmd:"" help:"Manage connection profiles"`
+}
+
+type ConfigProfilesCmd struct {
+	List   ConfigProfilesListCmd   `cmd:"" help:"List profiles"`
+	Add    ConfigProfilesAddCmd    `cmd:"" help:"Add a profile"`
+	Use    ConfigProfilesUseCmd    `cmd:"" help:"Set the default profile"`
+	Remove ConfigProfilesRemoveCmd `cmd:"" help:"Remove a profile"`
+}
+
+type ConfigProfilesListCmd struct{}
+
+type profileSummary struct {
+	Name     string `json:"name"`
+	Endpoint string `json:"endpoint"`
+	HasToken bool   `json:"hasToken"`
+	Current  bool   `json:"current"`
+}
+
+func (c *ConfigProfilesListCmd) Run(ctx *Context) error {
+	names := ctx.Config.ProfileNames()
+	profiles := make([]profileSummary, 0, len(names))
+	for _, name := range names {
+		p := ctx.Config.Profiles[name]
+		profiles = append(profiles, profileSummary{
+			Name:     name,
+			Endpoint: p.Endpoint,
+			HasToken: p.Token != "",
+			Current:  name == ctx.Config.Current,
+		})
+	}
+
+	if len(profiles) == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No profiles configured.\n")
+		return nil
+	}
+
+	t := out.NewTable("Current", "Name", "Endpoint", "Token")
+	for _, p := range profiles {
+		current := ""
+		if p.Current {
+			current = "*"
+		}
+		token := "no"
+		if p.HasToken {
+			token = "yes"
+		}
+		t.Row(current, p.Name, p.Endpoint, token)
+	}
+	return ctx.Print(profiles, t)
+}
+
+type ConfigProfilesAddCmd struct {
+	Name     string `arg:"" help:"Profile name"`
+	Endpoint string `name:"url" help:"Umami base URL for this profile" required:""`
+	Token    string `name:"api-token" help:"API token for this profile (or run auth login later)"`
+	Use      bool   `help:"Make this the default profile"`
+}
+
+func (c *ConfigProfilesAddCmd) Run(ctx *Context) error {
+	if err := ctx.Config.AddProfile(c.Name, c.Endpoint, c.Token); err != nil {
+		return err
+	}
+	if c.Use {
+		if err := ctx.Config.UseProfile(c.Name); err != nil {
+			return err
+		}
+	}
+	out.Printf("Profile %s added.\n", c.Name)
+	return nil
+}
+
+type ConfigProfilesUseCmd struct {
+	Name string `arg:"" help:"Profile name"`
+}
+
+func (c *ConfigProfilesUseCmd) Run(ctx *Context) error {
+	if err := ctx.Config.UseProfile(c.Name); err != nil {
+		return err
+	}
+	out.Printf("Default profile set to %s.\n", c.Name)
+	return nil
+}
+
+type ConfigProfilesRemoveCmd struct {
+	Name string `arg:"" help:"Profile name"`
+}
+
+func (c *ConfigProfilesRemoveCmd) Run(ctx *Context) error {
+	if err := ctx.Config.RemoveProfile(c.Name); err != nil {
+		return err
+	}
+	out.Printf("Profile %s removed.\n", c.Name)
+	return nil
+}
@@ -1,8 +1,46 @@
 package cmd
 
-import "github.com/yborunov/umami-cli/internal/config"
+import (
+	"time"
+
+	"github.com/yborunov/umami-cli/internal/config"
+	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
 
 type Context struct {
-	Config *config.Config
-	JSON   bool
+	Config  *config.Config
+	Output  out.Format
+	Retries int
+	Timeout time.Duration
+	// Cache enables the on-disk response cache for API requests.
+	Cache    bool
+	CacheTTL time.Duration
+}
+
+func (c *Context) API() (*umami.Client, error) {
+	if err := c.Config.RequireEndpoint(); err != nil {
+		return nil, err
+	}
+
+	retry := umami.DefaultRetryPolicy
+	retry.MaxAttempts = c.Retries + 1
+	opts := []umami.Option{
+		umami.WithRetry(retry),
+		umami.WithTimeout(c.Timeout),
+	}
+	if c.Cache {
+		cache, err := umami.NewCache("", c.CacheTTL)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, umami.WithCache(cache))
+	}
+	return umami.New(c.Config.Endpoint, c.Config.Token, opts...)
+}
+
+// Print renders data in the selected output format, using t for the
+// tabular formats.
+func (c *Context) Print(data any, t *out.Table) error {
+	return out.Print(c.Output, data, t)
 }
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+// Exit codes, one per error class, so scripts can react without parsing
+// messages.
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitAuth        = 3
+	exitNotFound    = 4
+	exitRateLimited = 5
+	exitServer      = 6
+	exitNetwork     = 7
+)
+
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	var apiErr *umami.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.IsUnauthorized():
+			return exitAuth
+		case apiErr.IsNotFound():
+			return exitNotFound
+		case apiErr.IsRateLimited():
+			return exitRateLimited
+		case apiErr.IsServerError():
+			return exitServer
+		}
+		return exitError
+	}
+	if errors.Is(err, umami.ErrMissingToken) {
+		return exitAuth
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return exitNetwork
+	}
+	return exitError
+}
+
+// errorHint returns a suggestion to print below the error, if any.
+func errorHint(err error) string {
+	var apiErr *umami.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Hint()
+	}
+	return ""
+}
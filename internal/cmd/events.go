@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+type EventsCmd struct {
+	List       EventsListCmd       `cmd:"" help:"Custom events and the properties sent with them"`
+	Fields     EventsFieldsCmd     `cmd:"" help:"Property fields across all events"`
+	Values     EventsValuesCmd     `cmd:"" help:"Values recorded for an event property"`
+	Stats      EventsStatsCmd      `cmd:"" help:"Event data totals"`
+	Properties EventsPropertiesCmd `cmd:"" help:"Property usage counts per event"`
+}
+
+type EventsListCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Event string `help:"Only show this event"`
+}
+
+func (c *EventsListCmd) Run(ctx *Context) error {
+	api, q, err := eventDataQuery(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+	if c.Event != "" {
+		q.Set("event", c.Event)
+	}
+
+	events, err := api.EventData.Events(context.Background(), c.WebsiteID, q)
+	if err != nil {
+		return err
+	}
+
+	if len(events) == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No event data found.\n")
+		return nil
+	}
+
+	return ctx.Print(events, eventDataEventsTable(events))
+}
+
+type EventsFieldsCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+}
+
+func (c *EventsFieldsCmd) Run(ctx *Context) error {
+	api, q, err := eventDataQuery(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+
+	fields, err := api.EventData.Fields(context.Background(), c.WebsiteID, q)
+	if err != nil {
+		return err
+	}
+
+	if len(fields) == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No event data found.\n")
+		return nil
+	}
+
+	return ctx.Print(fields, eventDataFieldsTable(fields))
+}
+
+type EventsValuesCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Event    string `help:"Event name" required:""`
+	Property string `help:"Property key" required:""`
+}
+
+func (c *EventsValuesCmd) Run(ctx *Context) error {
+	api, q, err := eventDataQuery(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+	q.Set("event", c.Event)
+	q.Set("propertyName", c.Property)
+
+	values, err := api.EventData.Values(context.Background(), c.WebsiteID, q)
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No values found for %s.%s.\n", c.Event, c.Property)
+		return nil
+	}
+
+	t := out.NewTable("Value", "Total")
+	for _, v := range values {
+		t.Row(v.Value, v.Total)
+	}
+	return ctx.Print(values, t)
+}
+
+type EventsStatsCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+}
+
+func (c *EventsStatsCmd) Run(ctx *Context) error {
+	api, q, err := eventDataQuery(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+
+	stats, err := api.EventData.Stats(context.Background(), c.WebsiteID, q)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Print(stats, eventDataStatsTable(stats))
+}
+
+type EventsPropertiesCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+}
+
+func (c *EventsPropertiesCmd) Run(ctx *Context) error {
+	api, q, err := eventDataQuery(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+
+	props, err := api.EventData.Properties(context.Background(), c.WebsiteID, q)
+	if err != nil {
+		return err
+	}
+
+	if len(props) == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No event properties found.\n")
+		return nil
+	}
+
+	return ctx.Print(props, eventDataPropertiesTable(props))
+}
+
+func eventDataEventsTable(events []umami.EventDataEvent) *out.Table {
+	t := out.NewTable("Event", "Property", "Type", "Total")
+	for _, e := range events {
+		t.Row(e.EventName, e.PropertyName, umami.DataTypeName(e.DataType), e.Total)
+	}
+	return t
+}
+
+func eventDataFieldsTable(fields []umami.EventDataField) *out.Table {
+	t := out.NewTable("Property", "Type", "Value", "Total")
+	for _, f := range fields {
+		t.Row(f.PropertyName, umami.DataTypeName(f.DataType), f.Value, f.Total)
+	}
+	return t
+}
+
+func eventDataStatsTable(stats *umami.EventDataStats) *out.Table {
+	t := out.NewTable("Metric", "Value")
+	t.Row("events", stats.Events)
+	t.Row("properties", stats.Properties)
+	t.Row("records", stats.Records)
+	return t
+}
+
+func eventDataPropertiesTable(props []umami.EventDataProperty) *out.Table {
+	t := out.NewTable("Event", "Property", "Total")
+	for _, p := range props {
+		t.Row(p.EventName, p.PropertyName, p.Total)
+	}
+	return t
+}
+
+// eventDataQuery validates the website ID, resolves the time range and
+// returns an API client alongside the base query shared by event data
+// commands.
+func eventDataQuery(ctx *Context, websiteID string, r TimeRange) (*umami.Client, url.Values, error) {
+	if err := validateWebsiteID(websiteID); err != nil {
+		return nil, nil, err
+	}
+	startAt, endAt, err := r.resolve(time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return nil, nil, err
+	}
+	return api, buildQuery(startAt, endAt, "", r.Timezone, Filters{}, 0, 0, "").Values(), nil
+}
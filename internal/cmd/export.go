@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+type ExportCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Dir    string `help:"Directory to write files to (created if missing)" default:"umami-export"`
+	Format string `help:"File format (csv|ndjson)" enum:"csv,ndjson" default:"csv"`
+	Unit   string `help:"Time unit for the pageviews series (year|month|day|hour)" default:"day"`
+	Limit  int    `help:"Rows per metric type (server default if unset)"`
+	Filters
+}
+
+type exportManifest struct {
+	WebsiteID   string            `json:"websiteId"`
+	Endpoint    string            `json:"endpoint"`
+	StartAt     time.Time         `json:"startAt"`
+	EndAt       time.Time         `json:"endAt"`
+	Timezone    string            `json:"timezone,omitempty"`
+	Unit        string            `json:"unit"`
+	Filters     map[string]string `json:"filters,omitempty"`
+	Format      string            `json:"format"`
+	GeneratedAt time.Time         `json:"generatedAt"`
+	Files       []exportFile      `json:"files"`
+}
+
+// exportFile describes one dataset. Filters are those applied to this
+// file, which can differ from the requested ones: event data endpoints
+// only take the time range, so those files never list any. Rows counts
+// the records written: CSV rows after the header, or NDJSON lines.
+type exportFile struct {
+	Dataset string            `json:"dataset"`
+	File    string            `json:"file,omitempty"`
+	Rows    int               `json:"rows"`
+	Filters map[string]string `json:"filters,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+func (c *ExportCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+	startAt, endAt, err := c.TimeRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+
+	filters := c.Filters.values()
+	m := &exportManifest{
+		WebsiteID:   c.WebsiteID,
+		Endpoint:    ctx.Config.Endpoint,
+		StartAt:     time.UnixMilli(startAt).UTC(),
+		EndAt:       time.UnixMilli(endAt).UTC(),
+		Timezone:    c.TimeRange.timezone(),
+		Unit:        c.Unit,
+		Filters:     filters,
+		Format:      c.Format,
+		GeneratedAt: time.Now().UTC(),
+	}
+	bg := context.Background()
+	q := buildQuery(startAt, endAt, "", c.Timezone, c.Filters, 0, 0, "")
+
+	stats, err := api.Analytics.Stats(bg, c.WebsiteID, q)
+	if err != nil {
+		return err
+	}
+	if err := c.write(m, "stats", filters, stats, statsTable(stats)); err != nil {
+		return err
+	}
+
+	pageviews, err := api.Analytics.Pageviews(bg, c.WebsiteID, buildQuery(startAt, endAt, c.Unit, c.Timezone, c.Filters, 0, 0, ""))
+	if err != nil {
+		return err
+	}
+	if err := c.write(m, "pageviews", filters, pageviews, pageviewsTable(pageviews)); err != nil {
+		return err
+	}
+
+	for _, metricType := range metricTypes {
+		dataset := "metrics-" + metricType
+		metrics, err := api.Analytics.Metrics(bg, c.WebsiteID, buildQuery(startAt, endAt, "", c.Timezone, c.Filters, c.Limit, 0, metricType))
+		// Older servers reject metric types they do not know; note them in
+		// the manifest and carry on with the rest.
+		var apiErr *umami.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+			fmt.Fprintf(os.Stderr, "skipped %s: %v\n", dataset, err)
+			m.Files = append(m.Files, exportFile{Dataset: dataset, Filters: filters, Error: err.Error()})
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := c.write(m, dataset, filters, metrics, metricsTable(metricType, metrics)); err != nil {
+			return err
+		}
+	}
+
+	sessions, err := api.Sessions.List(bg, c.WebsiteID, q.Values(), umami.ListParams{All: true})
+	if err != nil {
+		return err
+	}
+	if err := c.write(m, "sessions", filters, sessions.Data, sessionsExportTable(sessions.Data)); err != nil {
+		return err
+	}
+
+	// Event data endpoints only take the time range.
+	eq := buildQuery(startAt, endAt, "", c.Timezone, Filters{}, 0, 0, "").Values()
+	events, err := api.EventData.Events(bg, c.WebsiteID, eq)
+	if err != nil {
+		return err
+	}
+	if err := c.write(m, "event-data-events", nil, events, eventDataEventsTable(events)); err != nil {
+		return err
+	}
+	fields, err := api.EventData.Fields(bg, c.WebsiteID, eq)
+	if err != nil {
+		return err
+	}
+	if err := c.write(m, "event-data-fields", nil, fields, eventDataFieldsTable(fields)); err != nil {
+		return err
+	}
+	props, err := api.EventData.Properties(bg, c.WebsiteID, eq)
+	if err != nil {
+		return err
+	}
+	if err := c.write(m, "event-data-properties", nil, props, eventDataPropertiesTable(props)); err != nil {
+		return err
+	}
+	eventStats, err := api.EventData.Stats(bg, c.WebsiteID, eq)
+	if err != nil {
+		return err
+	}
+	if err := c.write(m, "event-data-stats", nil, eventStats, eventDataStatsTable(eventStats)); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(c.Dir, "manifest.json"), append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	t := out.NewTable("Dataset", "File", "Rows")
+	for _, f := range m.Files {
+		if f.Error != "" {
+			t.Row(f.Dataset, "(skipped)", 0)
+			continue
+		}
+		t.Row(f.Dataset, f.File, f.Rows)
+	}
+	return ctx.Print(m, t)
+}
+
+// write saves one dataset in the export format and records it in the
+// manifest with the filters it was fetched with. CSV files hold the
+// table; NDJSON files the API response.
+func (c *ExportCmd) write(m *exportManifest, dataset string, filters map[string]string, data any, t *out.Table) error {
+	name := dataset + "." + c.Format
+	f, err := os.Create(filepath.Join(c.Dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := &lineCounter{w: f}
+	if err := out.Fprint(w, out.Format(c.Format), data, t); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	rows := len(t.Rows)
+	if out.Format(c.Format) == out.FormatNDJSON {
+		rows = w.lines
+	}
+	m.Files = append(m.Files, exportFile{Dataset: dataset, File: name, Rows: rows, Filters: filters})
+	return f.Close()
+}
+
+// lineCounter counts the lines written through it. CSV fields can hold
+// newlines, so it is only used for NDJSON, where each line is one record.
+type lineCounter struct {
+	w     io.Writer
+	lines int
+}
+
+func (l *lineCounter) Write(p []byte) (int, error) {
+	n, err := l.w.Write(p)
+	l.lines += bytes.Count(p[:n], []byte{'\n'})
+	return n, err
+}
+
+// sessionsExportTable keeps every session field, with UTC timestamps, so
+// exported files do not depend on the local timezone.
+func sessionsExportTable(sessions []umami.Session) *out.Table {
+	t := out.NewTable("ID", "Distinct ID", "Hostname", "Browser", "OS", "Device", "Screen", "Language",
+		"Country", "Region", "City", "First at", "Last at", "Visits", "Views", "Events", "Total time")
+	for _, s := range sessions {
+		t.Row(s.ID, s.DistinctID, s.Hostname, s.Browser, s.OS, s.Device, s.Screen, s.Language,
+			s.Country, s.Region, s.City, exportTime(s.FirstAt), exportTime(s.LastAt), s.Visits, s.Views, s.Events, s.TotalTime)
+	}
+	return t
+}
+
+func exportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+type Pagination struct {
+	Page     int    `help:"Page number to fetch (default 1)"`
+	PageSize int    `help:"Results per page (server default if unset)"`
+	All      bool   `help:"Fetch every page"`
+	Search   string `help:"Only return results matching a search term"`
+}
+
+func (p Pagination) params() umami.ListParams {
+	return umami.ListParams{
+		Page:     p.Page,
+		PageSize: p.PageSize,
+		Search:   p.Search,
+		All:      p.All,
+	}
+}
+
+// printList renders a list result, printing empty instead of a header-only
+// table when nothing matched. In table output it notes on stderr when
+// further pages exist.
+func printList[T any](ctx *Context, res *umami.ListResult[T], t *out.Table, empty string) error {
+	if len(res.Data) == 0 && ctx.Output == out.FormatTable {
+		out.Printf("%s\n", empty)
+		return nil
+	}
+	if err := ctx.Print(res.Data, t); err != nil {
+		return err
+	}
+	if ctx.Output == out.FormatTable && res.More() {
+		fmt.Fprintf(os.Stderr, "Showing page %d (%d of %d). Use --page or --all to see more.\n", res.Page, len(res.Data), res.Count)
+	}
+	return nil
+}
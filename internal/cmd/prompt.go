@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// confirm asks the user to approve a destructive operation unless yes is
+// set. Anything other than "y" or "yes" aborts.
+func confirm(yes bool, format string, args ...any) error {
+	if yes {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, format+" [y/N]: ", args...)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return errors.New("aborted: no confirmation received (use --yes to skip)")
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return nil
+	}
+	return errors.New("aborted")
+}
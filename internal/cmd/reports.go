@@ -0,0 +1,778 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+type ReportsCmd struct {
+	Funnel      ReportsFunnelCmd      `cmd:"" help:"Conversion through a sequence of pages or events"`
+	Retention   ReportsRetentionCmd   `cmd:"" help:"Returning visitor cohorts by day"`
+	Journey     ReportsJourneyCmd     `cmd:"" help:"Most common paths through the site (supports -o dot)"`
+	UTM         ReportsUTMCmd         `cmd:"" name:"utm" help:"Visitors by UTM source, medium, campaign, content and term"`
+	Attribution ReportsAttributionCmd `cmd:"" help:"Which referrers and campaigns lead to a conversion goal"`
+	Goals       ReportsGoalsCmd       `cmd:"" help:"Visitors completing one or more goals"`
+	Revenue     ReportsRevenueCmd     `cmd:"" help:"Revenue totals, by country and over time"`
+	Saved       ReportsSavedCmd       `cmd:"" help:"Manage and run reports saved in Umami"`
+}
+
+type ReportsFunnelCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Steps  []string `name:"step" sep:"none" required:"" help:"Funnel step in order: a path (/pricing), path:/pricing or event:purchase (repeatable)"`
+	Window int      `help:"Minutes allowed between the first and last step" default:"60"`
+	Filters
+}
+
+func (c *ReportsFunnelCmd) Run(ctx *Context) error {
+	steps := make([]umami.Step, 0, len(c.Steps))
+	for _, s := range c.Steps {
+		step, err := parseStep(s)
+		if err != nil {
+			return err
+		}
+		steps = append(steps, step)
+	}
+
+	api, dates, err := reportRange(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+
+	result, err := api.Reports.Funnel(context.Background(), c.WebsiteID, umami.FunnelParams{
+		DateRange: dates,
+		Window:    c.Window,
+		Steps:     steps,
+	}, c.Filters.values())
+	if err != nil {
+		return err
+	}
+	return ctx.Print(result, funnelTable(result))
+}
+
+func funnelTable(steps []umami.FunnelStep) *out.Table {
+	t := out.NewTable("Step", "Type", "Value", "Visitors", "Drop-off", "Conversion")
+	var first int64
+	for i, step := range steps {
+		if i == 0 {
+			first = step.Visitors
+		}
+		dropped := int64(0)
+		if i > 0 {
+			dropped = steps[i-1].Visitors - step.Visitors
+		}
+		t.Row(i+1, step.Type, step.Value, step.Visitors, dropped, percent(step.Visitors, first))
+	}
+	return t
+}
+
+type ReportsRetentionCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Filters
+}
+
+func (c *ReportsRetentionCmd) Run(ctx *Context) error {
+	api, dates, err := reportRange(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+
+	result, err := api.Reports.Retention(context.Background(), c.WebsiteID, dates, c.Filters.values())
+	if err != nil {
+		return err
+	}
+	return ctx.Print(result, retentionTable(result))
+}
+
+// retentionTable lays the cohorts out as the classic triangle: one row
+// per cohort date, one column per day since first visit.
+func retentionTable(cohorts []umami.RetentionCohort) *out.Table {
+	var dates []string
+	maxDay := 0
+	size := map[string]int64{}
+	cells := map[string]map[int]float64{}
+	for _, c := range cohorts {
+		if _, ok := cells[c.Date]; !ok {
+			dates = append(dates, c.Date)
+			cells[c.Date] = map[int]float64{}
+		}
+		cells[c.Date][c.Day] = c.Percentage
+		if c.Day == 0 || size[c.Date] == 0 {
+			size[c.Date] = c.Visitors
+		}
+		maxDay = max(maxDay, c.Day)
+	}
+	sort.Strings(dates)
+
+	headers := []string{"Cohort", "Visitors"}
+	for day := 0; day <= maxDay; day++ {
+		headers = append(headers, fmt.Sprintf("Day %d", day))
+	}
+	t := out.NewTable(headers...)
+	for _, date := range dates {
+		row := []any{shortDate(date), size[date]}
+		for day := 0; day <= maxDay; day++ {
+			cell := ""
+			if pct, ok := cells[date][day]; ok {
+				cell = fmt.Sprintf("%.1f%%", pct)
+			}
+			row = append(row, cell)
+		}
+		t.Row(row...)
+	}
+	return t
+}
+
+type ReportsJourneyCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Steps     int    `help:"Number of steps per path (3-7)" default:"5"`
+	StartStep string `help:"Only include paths starting at this page or event"`
+	EndStep   string `help:"Only include paths ending at this page or event"`
+	Filters
+}
+
+func (c *ReportsJourneyCmd) Run(ctx *Context) error {
+	api, dates, err := reportRange(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+
+	result, err := api.Reports.Journey(context.Background(), c.WebsiteID, umami.JourneyParams{
+		DateRange: dates,
+		Steps:     c.Steps,
+		StartStep: c.StartStep,
+		EndStep:   c.EndStep,
+	}, c.Filters.values())
+	if err != nil {
+		return err
+	}
+	return printJourneys(ctx, result)
+}
+
+func printJourneys(ctx *Context, journeys []umami.Journey) error {
+	if ctx.Output == out.FormatDOT {
+		out.Printf("%s", journeyDOT(journeys))
+		return nil
+	}
+	if len(journeys) == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No journeys found.\n")
+		return nil
+	}
+
+	t := out.NewTable("Count", "Path")
+	for _, j := range journeys {
+		t.Row(j.Count, strings.Join(journeySteps(j), " → "))
+	}
+	return ctx.Print(journeys, t)
+}
+
+func journeySteps(j umami.Journey) []string {
+	steps := make([]string, 0, len(j.Items))
+	for _, item := range j.Items {
+		if item == nil {
+			break
+		}
+		steps = append(steps, *item)
+	}
+	return steps
+}
+
+// journeyDOT renders page transitions as a Graphviz digraph, with edges
+// labelled by the number of visitors taking them.
+func journeyDOT(journeys []umami.Journey) string {
+	type edge struct{ from, to string }
+	weights := map[edge]int64{}
+	var order []edge
+	for _, j := range journeys {
+		steps := journeySteps(j)
+		for i := 1; i < len(steps); i++ {
+			e := edge{steps[i-1], steps[i]}
+			if _, ok := weights[e]; !ok {
+				order = append(order, e)
+			}
+			weights[e] += j.Count
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph journey {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, e := range order {
+		fmt.Fprintf(&b, "  %s -> %s [label=\"%d\"];\n", dotQuote(e.from), dotQuote(e.to), weights[e])
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote quotes s as a DOT string. Only " and \ need escaping; other
+// characters, including non-ASCII ones, are written as is.
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+type ReportsUTMCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Filters
+}
+
+func (c *ReportsUTMCmd) Run(ctx *Context) error {
+	api, dates, err := reportRange(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+
+	result, err := api.Reports.UTM(context.Background(), c.WebsiteID, dates, c.Filters.values())
+	if err != nil {
+		return err
+	}
+	return printUTM(ctx, result)
+}
+
+func printUTM(ctx *Context, utm *umami.UTMReport) error {
+	t, rows := namedValuesTable("Parameter", "Visitors", []namedGroup{
+		{"source", utm.Source},
+		{"medium", utm.Medium},
+		{"campaign", utm.Campaign},
+		{"content", utm.Content},
+		{"term", utm.Term},
+	})
+	if rows == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No UTM parameters found.\n")
+		return nil
+	}
+	return ctx.Print(utm, t)
+}
+
+type ReportsAttributionCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Model string `help:"Attribution model (first-click|last-click)" enum:"first-click,last-click" default:"first-click"`
+	Goal  string `required:"" help:"Conversion goal: a path (/thanks), path:/thanks or event:signup"`
+	Filters
+}
+
+func (c *ReportsAttributionCmd) Run(ctx *Context) error {
+	goal, err := parseStep(c.Goal)
+	if err != nil {
+		return err
+	}
+
+	api, dates, err := reportRange(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+
+	model := umami.AttributionFirstClick
+	if c.Model == "last-click" {
+		model = umami.AttributionLastClick
+	}
+	result, err := api.Reports.Attribution(context.Background(), c.WebsiteID, umami.AttributionParams{
+		DateRange: dates,
+		Model:     model,
+		Type:      goal.Type,
+		Step:      goal.Value,
+	}, c.Filters.values())
+	if err != nil {
+		return err
+	}
+	return printAttribution(ctx, result)
+}
+
+func printAttribution(ctx *Context, a *umami.AttributionReport) error {
+	t, rows := namedValuesTable("Source", "Conversions", []namedGroup{
+		{"referrer", a.Referrer},
+		{"paid ads", a.PaidAds},
+		{"utm source", a.UTMSource},
+		{"utm medium", a.UTMMedium},
+		{"utm campaign", a.UTMCampaign},
+		{"utm content", a.UTMContent},
+		{"utm term", a.UTMTerm},
+	})
+	if rows == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No conversions found.\n")
+		return nil
+	}
+	return ctx.Print(a, t)
+}
+
+type ReportsGoalsCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Goals []string `name:"goal" sep:"none" required:"" help:"Goal: a path (/thanks), path:/thanks or event:purchase (repeatable)"`
+	Filters
+}
+
+func (c *ReportsGoalsCmd) Run(ctx *Context) error {
+	goals := make([]umami.Step, 0, len(c.Goals))
+	for _, g := range c.Goals {
+		goal, err := parseStep(g)
+		if err != nil {
+			return err
+		}
+		goals = append(goals, goal)
+	}
+
+	api, dates, err := reportRange(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+
+	result, err := api.Reports.Goals(context.Background(), c.WebsiteID, dates, goals, c.Filters.values())
+	if err != nil {
+		return err
+	}
+	return ctx.Print(result, goalsTable(result))
+}
+
+func goalsTable(goals []umami.GoalResult) *out.Table {
+	t := out.NewTable("Type", "Value", "Conversions", "Visitors", "Rate")
+	for _, g := range goals {
+		t.Row(g.Type, g.Value, g.Num, g.Total, percent(g.Num, g.Total))
+	}
+	return t
+}
+
+type ReportsRevenueCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Currency string `required:"" help:"Currency code (e.g. USD)"`
+	Unit     string `help:"Time unit for the series (year|month|day|hour)"`
+	By       string `help:"Breakdown shown in tabular output (total|country|time)" enum:"total,country,time" default:"total"`
+	Filters
+}
+
+func (c *ReportsRevenueCmd) Run(ctx *Context) error {
+	api, dates, err := reportRange(ctx, c.WebsiteID, c.TimeRange)
+	if err != nil {
+		return err
+	}
+
+	result, err := api.Reports.Revenue(context.Background(), c.WebsiteID, umami.RevenueParams{
+		DateRange: dates,
+		Unit:      c.Unit,
+		Currency:  strings.ToUpper(c.Currency),
+	}, c.Filters.values())
+	if err != nil {
+		return err
+	}
+	return ctx.Print(result, revenueTable(result, c.By, strings.ToUpper(c.Currency)))
+}
+
+func revenueTable(r *umami.Revenue, by, currency string) *out.Table {
+	var t *out.Table
+	switch by {
+	case "country":
+		t = out.NewTable("Country", "Revenue", "Share")
+		for _, c := range r.Country {
+			t.Row(c.Name, money(c.Value), share(c.Value, r.Total.Sum))
+		}
+	case "time":
+		t = out.NewTable("Time", "Event", "Revenue")
+		for _, p := range r.Chart {
+			t.Row(p.T, p.X, money(p.Y))
+		}
+	default:
+		t = out.NewTable("Currency", "Revenue", "Transactions", "Customers", "Average")
+		t.Row(currency, money(r.Total.Sum), r.Total.Count, r.Total.UniqueCount, money(r.Total.Average))
+	}
+	return t
+}
+
+func money(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func share(part, total float64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", part*100/total)
+}
+
+type ReportsSavedCmd struct {
+	List   ReportsSavedListCmd   `cmd:"" help:"List saved reports"`
+	Get    ReportsSavedGetCmd    `cmd:"" help:"Show a saved report"`
+	Create ReportsSavedCreateCmd `cmd:"" help:"Save a report definition"`
+	Update ReportsSavedUpdateCmd `cmd:"" help:"Update a saved report"`
+	Delete ReportsSavedDeleteCmd `cmd:"" help:"Delete a saved report"`
+	Run    ReportsSavedRunCmd    `cmd:"" help:"Run a saved report with its stored parameters"`
+}
+
+type ReportsSavedListCmd struct {
+	WebsiteID string `arg:"" name:"website-id" optional:"" help:"Only list reports for this website"`
+	Pagination
+}
+
+func (c *ReportsSavedListCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Reports.List(context.Background(), c.WebsiteID, c.params())
+	if err != nil {
+		return err
+	}
+	return printList(ctx, res, savedReportsTable(res.Data), "No saved reports found.")
+}
+
+type ReportsSavedGetCmd struct {
+	ReportID string `arg:"" name:"report-id" help:"Report ID"`
+}
+
+func (c *ReportsSavedGetCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	report, err := api.Reports.Get(context.Background(), c.ReportID)
+	if err != nil {
+		return err
+	}
+	return ctx.Print(report, savedReportsTable([]umami.SavedReport{*report}))
+}
+
+type ReportsSavedCreateCmd struct {
+	WebsiteID   string `arg:"" name:"website-id" help:"Website ID"`
+	Type        string `required:"" help:"Report type (funnel|retention|journey|utm|attribution|goal|revenue)"`
+	Name        string `required:"" help:"Report name"`
+	Description string `help:"Report description"`
+	Parameters  string `help:"Report parameters as JSON, or @file to read them from a file" default:"{}"`
+}
+
+func (c *ReportsSavedCreateCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+	params, err := readParameters(c.Parameters)
+	if err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	report, err := api.Reports.Create(context.Background(), umami.SavedReportParams{
+		WebsiteID:   c.WebsiteID,
+		Type:        c.Type,
+		Name:        c.Name,
+		Description: c.Description,
+		Parameters:  params,
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.Print(report, savedReportsTable([]umami.SavedReport{*report}))
+}
+
+type ReportsSavedUpdateCmd struct {
+	ReportID    string `arg:"" name:"report-id" help:"Report ID"`
+	Name        string `help:"New report name"`
+	Description string `help:"New report description"`
+	Parameters  string `help:"New report parameters as JSON, or @file to read them from a file"`
+}
+
+func (c *ReportsSavedUpdateCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	// Umami replaces the whole report on update, so unchanged fields are
+	// carried over from the stored copy.
+	report, err := api.Reports.Get(context.Background(), c.ReportID)
+	if err != nil {
+		return err
+	}
+	params := umami.SavedReportParams{
+		WebsiteID:   report.WebsiteID,
+		Type:        report.Type,
+		Name:        report.Name,
+		Description: report.Description,
+		Parameters:  report.Parameters,
+	}
+	if c.Name != "" {
+		params.Name = c.Name
+	}
+	if c.Description != "" {
+		params.Description = c.Description
+	}
+	if c.Parameters != "" {
+		if params.Parameters, err = readParameters(c.Parameters); err != nil {
+			return err
+		}
+	}
+
+	report, err = api.Reports.Update(context.Background(), c.ReportID, params)
+	if err != nil {
+		return err
+	}
+	return ctx.Print(report, savedReportsTable([]umami.SavedReport{*report}))
+}
+
+type ReportsSavedDeleteCmd struct {
+	ReportID string `arg:"" name:"report-id" help:"Report ID"`
+	Yes      bool   `short:"y" help:"Skip confirmation prompt"`
+}
+
+func (c *ReportsSavedDeleteCmd) Run(ctx *Context) error {
+	if err := confirm(c.Yes, "Delete saved report %s?", c.ReportID); err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	if err := api.Reports.Delete(context.Background(), c.ReportID); err != nil {
+		return err
+	}
+	out.Printf("Report %s deleted.\n", c.ReportID)
+	return nil
+}
+
+type ReportsSavedRunCmd struct {
+	ReportID string `arg:"" name:"report-id" help:"Report ID"`
+	TimeRange
+	By string `help:"Breakdown shown for revenue reports in tabular output (total|country|time)" enum:"total,country,time" default:"total"`
+	Filters
+}
+
+// Run loads the stored parameters into the typed parameters of the
+// matching report, so results render exactly like the report commands.
+// The stored date range is used unless range flags are given, and filter
+// flags override stored filters of the same name.
+func (c *ReportsSavedRunCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	bg := context.Background()
+	report, err := api.Reports.Get(bg, c.ReportID)
+	if err != nil {
+		return err
+	}
+	if ctx.Output == out.FormatDOT && report.Type != "journey" {
+		return fmt.Errorf("dot output is only supported by journey reports, not %s", report.Type)
+	}
+	dates, filters, stored, err := report.Query()
+	if err != nil {
+		return err
+	}
+	if c.TimeRange.set() || !stored {
+		if dates, err = reportDates(report.WebsiteID, c.TimeRange); err != nil {
+			return err
+		}
+	} else if c.Timezone != "" || dates.Timezone == "" {
+		dates.Timezone = c.TimeRange.timezone()
+	}
+	if filters == nil {
+		filters = umami.Filters{}
+	}
+	for name, value := range c.Filters.values() {
+		filters[name] = value
+	}
+	websiteID := report.WebsiteID
+
+	switch report.Type {
+	case "funnel":
+		var params umami.FunnelParams
+		if err := report.DecodeParameters(&params); err != nil {
+			return err
+		}
+		params.DateRange = dates
+		result, err := api.Reports.Funnel(bg, websiteID, params, filters)
+		if err != nil {
+			return err
+		}
+		return ctx.Print(result, funnelTable(result))
+	case "retention":
+		result, err := api.Reports.Retention(bg, websiteID, dates, filters)
+		if err != nil {
+			return err
+		}
+		return ctx.Print(result, retentionTable(result))
+	case "journey":
+		var params umami.JourneyParams
+		if err := report.DecodeParameters(&params); err != nil {
+			return err
+		}
+		params.DateRange = dates
+		result, err := api.Reports.Journey(bg, websiteID, params, filters)
+		if err != nil {
+			return err
+		}
+		return printJourneys(ctx, result)
+	case "utm":
+		result, err := api.Reports.UTM(bg, websiteID, dates, filters)
+		if err != nil {
+			return err
+		}
+		return printUTM(ctx, result)
+	case "attribution":
+		var params umami.AttributionParams
+		if err := report.DecodeParameters(&params); err != nil {
+			return err
+		}
+		params.DateRange = dates
+		result, err := api.Reports.Attribution(bg, websiteID, params, filters)
+		if err != nil {
+			return err
+		}
+		return printAttribution(ctx, result)
+	case "goal":
+		var params umami.GoalParams
+		if err := report.DecodeParameters(&params); err != nil {
+			return err
+		}
+		params.DateRange = dates
+		result, err := api.Reports.Goal(bg, websiteID, params, filters)
+		if err != nil {
+			return err
+		}
+		return ctx.Print(result, goalsTable([]umami.GoalResult{*result}))
+	case "revenue":
+		var params umami.RevenueParams
+		if err := report.DecodeParameters(&params); err != nil {
+			return err
+		}
+		params.DateRange = dates
+		result, err := api.Reports.Revenue(bg, websiteID, params, filters)
+		if err != nil {
+			return err
+		}
+		return ctx.Print(result, revenueTable(result, c.By, params.Currency))
+	}
+	return fmt.Errorf("running %q reports is not supported", report.Type)
+}
+
+func savedReportsTable(reports []umami.SavedReport) *out.Table {
+	t := out.NewTable("ID", "Name", "Type", "Website ID", "Description", "Updated")
+	for _, r := range reports {
+		t.Row(r.ID, r.Name, r.Type, r.WebsiteID, r.Description, formatDate(r.UpdatedAt))
+	}
+	return t
+}
+
+// readParameters reads report parameters given inline or as @file and
+// checks they are a JSON object.
+func readParameters(value string) (json.RawMessage, error) {
+	data := []byte(value)
+	if name, ok := strings.CutPrefix(value, "@"); ok {
+		var err error
+		if data, err = os.ReadFile(name); err != nil {
+			return nil, err
+		}
+	}
+
+	var params map[string]any
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+	return json.RawMessage(data), nil
+}
+
+type namedGroup struct {
+	name   string
+	values umami.NamedValues
+}
+
+// namedValuesTable flattens grouped counts into one table, with each
+// row's share of its group, and reports how many rows it holds.
+func namedValuesTable(groupHeader, countHeader string, groups []namedGroup) (*out.Table, int) {
+	t := out.NewTable(groupHeader, "Value", countHeader, "Share")
+	rows := 0
+	for _, g := range groups {
+		var total int64
+		for _, v := range g.values {
+			total += v.Value
+		}
+		for _, v := range g.values {
+			t.Row(g.name, v.Name, v.Value, percent(v.Value, total))
+			rows++
+		}
+	}
+	return t, rows
+}
+
+// parseStep reads a report step. Bare values starting with "/" are paths;
+// anything else needs a "path:" or "event:" prefix.
+func parseStep(value string) (umami.Step, error) {
+	if strings.HasPrefix(value, "/") {
+		return umami.Step{Type: umami.StepTypePath, Value: value}, nil
+	}
+	kind, v, ok := strings.Cut(value, ":")
+	if ok && v != "" {
+		switch kind {
+		case umami.StepTypePath, umami.StepTypeEvent:
+			return umami.Step{Type: kind, Value: v}, nil
+		}
+	}
+	return umami.Step{}, fmt.Errorf("invalid step %q: use /path, path:/path or event:name", value)
+}
+
+// reportRange validates the website ID and resolves the time range into
+// the date range sent with every report.
+func reportRange(ctx *Context, websiteID string, r TimeRange) (*umami.Client, umami.DateRange, error) {
+	dates, err := reportDates(websiteID, r)
+	if err != nil {
+		return nil, umami.DateRange{}, err
+	}
+	api, err := ctx.API()
+	if err != nil {
+		return nil, umami.DateRange{}, err
+	}
+	return api, dates, nil
+}
+
+// reportDates validates the website ID and resolves r into a report date
+// range.
+func reportDates(websiteID string, r TimeRange) (umami.DateRange, error) {
+	if err := validateWebsiteID(websiteID); err != nil {
+		return umami.DateRange{}, err
+	}
+	startAt, endAt, err := r.resolve(time.Now())
+	if err != nil {
+		return umami.DateRange{}, err
+	}
+	return umami.DateRange{
+		StartDate: time.UnixMilli(startAt).UTC(),
+		EndDate:   time.UnixMilli(endAt).UTC(),
+		Timezone:  r.timezone(),
+	}, nil
+}
+
+// shortDate trims an ISO timestamp returned by a report to its date.
+func shortDate(value string) string {
+	if len(value) >= 10 {
+		return value[:10]
+	}
+	return value
+}
+
+func percent(part, total int64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
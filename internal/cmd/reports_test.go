@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+func steps(values ...string) []*string {
+	items := make([]*string, len(values))
+	for i := range values {
+		items[i] = &values[i]
+	}
+	return items
+}
+
+func TestJourneyDOT(t *testing.T) {
+	got := journeyDOT([]umami.Journey{
+		{Items: steps("/café", `/say "hi"`, `C:\path`), Count: 3},
+		{Items: append(steps("/café", `/say "hi"`), nil), Count: 2},
+	})
+	want := `digraph journey {
+  rankdir=LR;
+  node [shape=box];
+  "/café" -> "/say \"hi\"" [label="5"];
+  "/say \"hi\"" -> "C:\\path" [label="3"];
+}
+`
+	if got != want {
+		t.Errorf("journeyDOT:\n%s\nwant:\n%s", got, want)
+	}
+}
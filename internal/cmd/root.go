@@ -3,15 +3,23 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/yborunov/umami-cli/internal/config"
+	"github.com/yborunov/umami-cli/internal/out"
 )
 
 type Globals struct {
-	Endpoint string `help:"Umami base URL (e.g. https://analytics.example.com)" env:"UMAMI_URL"`
-	Token    string `help:"API token (overrides stored config)" env:"UMAMI_TOKEN"`
-	JSON     bool   `help:"Output raw JSON"`
+	Profile  string        `help:"Config profile to use" env:"UMAMI_PROFILE"`
+	Endpoint string        `help:"Umami base URL (e.g. https://analytics.example.com)" env:"UMAMI_URL"`
+	Token    string        `help:"API token (overrides stored config)" env:"UMAMI_TOKEN"`
+	Retries  int           `help:"Retries for failed idempotent requests (network errors, 429, 5xx)" default:"2" env:"UMAMI_RETRIES"`
+	Timeout  time.Duration `help:"Timeout for each HTTP request" default:"30s" env:"UMAMI_TIMEOUT"`
+	CacheTTL time.Duration `help:"How long cached responses for past ranges stay valid (0 disables the cache)" default:"1h" env:"UMAMI_CACHE_TTL"`
+	NoCache  bool          `help:"Bypass the response cache" env:"UMAMI_NO_CACHE"`
+	Output   string        `short:"o" help:"Output format (table|json|ndjson|csv|tsv|markdown|yaml|dot)" enum:"table,json,ndjson,csv,tsv,markdown,yaml,dot" default:"table" env:"UMAMI_OUTPUT"`
 }
 
 type CLI struct {
@@ -19,7 +27,17 @@ type CLI struct {
 
 	Auth      AuthCmd      `cmd:"" help:"Authenticate and manage tokens"`
 	Analytics AnalyticsCmd `cmd:"" help:"Analytics operations"`
+	Cache     CacheCmd     `cmd:"" help:"Inspect and clear the response cache"`
+	Config    ConfigCmd    `cmd:"" help:"Manage CLI configuration"`
+	Events    EventsCmd    `cmd:"" help:"Custom event data and properties"`
+	Export    ExportCmd    `cmd:"" help:"Export analytics data to CSV or NDJSON files"`
+	Me        MeCmd        `cmd:"" help:"Current user, websites and teams"`
+	Reports   ReportsCmd   `cmd:"" help:"Run analytics reports"`
+	Send      SendCmd      `cmd:"" help:"Send tracking events to the collector (no token needed)"`
+	Sessions  SessionsCmd  `cmd:"" help:"Explore visitor sessions"`
+	Store     StoreCmd     `cmd:"" help:"Local snapshot history of stats and metrics"`
 	Teams     TeamsCmd     `cmd:"" help:"Team operations"`
+	Users     UsersCmd     `cmd:"" help:"User administration (admin only)"`
 	Websites  WebsitesCmd  `cmd:"" help:"Website operations"`
 	Version   VersionCmd   `cmd:"" help:"Print version"`
 }
@@ -30,22 +48,48 @@ func Run() int {
 		kong.Name("umami"),
 		kong.Description("CLI for Umami Analytics API"),
 		kong.UsageOnError(),
+		kong.Vars{"metric_types": strings.Join(metricTypes, "|")},
+		kong.Exit(func(code int) {
+			// Kong exits with 1 on parse errors; report them as usage errors.
+			if code == exitError {
+				code = exitUsage
+			}
+			os.Exit(code)
+		}),
 	)
 
-	cfg, err := config.Load(cli.Endpoint, cli.Token)
+	// Reject dot before any request is made, rather than after fetching
+	// data that cannot be rendered as a graph.
+	if out.Format(cli.Output) == out.FormatDOT && !supportsDOT(kctx.Command()) {
+		fmt.Fprintln(os.Stderr, out.ErrDOTUnsupported)
+		return exitUsage
+	}
+
+	cfg, err := config.Load(cli.Profile, cli.Endpoint, cli.Token)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		return 1
+		return exitError
 	}
 
 	ctx := &Context{
-		Config: cfg,
-		JSON:   cli.JSON,
+		Config:   cfg,
+		Output:   out.Format(cli.Output),
+		Retries:  cli.Retries,
+		Timeout:  cli.Timeout,
+		Cache:    cli.CacheTTL > 0 && !cli.NoCache,
+		CacheTTL: cli.CacheTTL,
 	}
 
 	if err := kctx.Run(ctx); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		return 1
+		if hint := errorHint(err); hint != "" {
+			fmt.Fprintln(os.Stderr, "hint:", hint)
+		}
+		return exitCode(err)
 	}
-	return 0
+	return exitOK
+}
+
+func supportsDOT(command string) bool {
+	return strings.HasPrefix(command, "reports journey") || strings.HasPrefix(command, "reports saved run")
 }
@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+// batchSize is the number of events sent per /batch request.
+const batchSize = 100
+
+type SendCmd struct {
+	Pageview SendPageviewCmd `cmd:"" help:"Send a pageview"`
+	Event    SendEventCmd    `cmd:"" help:"Send a custom event"`
+	Identify SendIdentifyCmd `cmd:"" help:"Link a distinct ID and session data to a session"`
+}
+
+// SendFlags are the collector payload fields shared by the send commands.
+// With --batch they act as defaults for fields missing from each line.
+type SendFlags struct {
+	URL       string   `help:"Page URL or path"`
+	Title     string   `help:"Page title"`
+	Referrer  string   `help:"Referrer URL"`
+	Hostname  string   `help:"Hostname (defaults to the host of --url)"`
+	Language  string   `help:"Browser language (e.g. en-US)"`
+	Screen    string   `help:"Screen size (e.g. 1920x1080)"`
+	Data      []string `sep:"none" help:"Event data as key=value (repeatable)"`
+	Timestamp string   `help:"Backdate the event (ms since epoch, RFC3339, or YYYY-MM-DD)"`
+	Timezone  string   `help:"Timezone for --timestamp values without an offset (default UTC)"`
+	UserAgent string   `help:"User-Agent reported to Umami, which derives the browser, OS and device from it (required)" env:"UMAMI_USER_AGENT"`
+	Batch     string   `help:"Send events from an NDJSON file (- for stdin) in /batch requests" placeholder:"FILE"`
+}
+
+type SendPageviewCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	SendFlags
+}
+
+func (c *SendPageviewCmd) Run(ctx *Context) error {
+	if c.Batch != "" {
+		return c.sendBatch(ctx, umami.EventTypeEvent, c.WebsiteID, "", "")
+	}
+	if c.URL == "" {
+		return errors.New("url is required")
+	}
+	return c.send(ctx, umami.EventTypeEvent, c.WebsiteID, "", "")
+}
+
+type SendEventCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	Name      string `help:"Event name"`
+	SendFlags
+}
+
+func (c *SendEventCmd) Run(ctx *Context) error {
+	if c.Batch != "" {
+		return c.sendBatch(ctx, umami.EventTypeEvent, c.WebsiteID, c.Name, "")
+	}
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	return c.send(ctx, umami.EventTypeEvent, c.WebsiteID, c.Name, "")
+}
+
+type SendIdentifyCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	ID        string `help:"Distinct ID of the user (e.g. your internal user ID)"`
+	SendFlags
+}
+
+// Run sends an identify event. With --batch each line is a payload such
+// as {"id": "user-1", "data": {"plan": "pro"}}, so a backend can pipe
+// signups in on stdin with --batch -.
+func (c *SendIdentifyCmd) Run(ctx *Context) error {
+	if c.Batch != "" {
+		return c.sendBatch(ctx, umami.EventTypeIdentify, c.WebsiteID, "", c.ID)
+	}
+	if c.ID == "" && len(c.Data) == 0 {
+		return errors.New("id or data is required")
+	}
+	return c.send(ctx, umami.EventTypeIdentify, c.WebsiteID, "", c.ID)
+}
+
+func (f SendFlags) send(ctx *Context, eventType, websiteID, name, id string) error {
+	if err := validateWebsiteID(websiteID); err != nil {
+		return err
+	}
+	payload, err := f.payload(websiteID, name, id)
+	if err != nil {
+		return err
+	}
+
+	api, err := f.collector(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Collector.Send(context.Background(), umami.SendEvent{Type: eventType, Payload: payload})
+	if err != nil {
+		return err
+	}
+	t := out.NewTable("Session ID", "Visit ID")
+	t.Row(res.SessionID, res.VisitID)
+	return ctx.Print(res, t)
+}
+
+// sendBatch reads one event per line. A line is either a full collector
+// event ({"type": ..., "payload": {...}}) or just a payload, which is sent
+// as eventType. Flags fill in fields a line leaves empty.
+func (f SendFlags) sendBatch(ctx *Context, eventType, websiteID, name, id string) error {
+	defaults, err := f.payload(websiteID, name, id)
+	if err != nil {
+		return err
+	}
+	events, err := readEvents(f.Batch, eventType, defaults)
+	if err != nil {
+		return err
+	}
+
+	api, err := f.collector(ctx)
+	if err != nil {
+		return err
+	}
+
+	total := &umami.BatchResult{}
+	for start := 0; start < len(events); start += batchSize {
+		chunk := events[start:min(start+batchSize, len(events))]
+		res, err := api.Collector.Batch(context.Background(), chunk)
+		if err != nil {
+			return fmt.Errorf("batch starting at event %d: %w", start+1, err)
+		}
+		total.Size += res.Size
+		total.Processed += res.Processed
+		total.Errors += res.Errors
+	}
+
+	t := out.NewTable("Events", "Processed", "Errors")
+	t.Row(total.Size, total.Processed, total.Errors)
+	return ctx.Print(total, t)
+}
+
+func (f SendFlags) collector(ctx *Context) (*umami.Client, error) {
+	api, err := ctx.API()
+	if err != nil {
+		return nil, err
+	}
+	// There is no default: Umami records the browser, OS and device from
+	// the User-Agent, so any made-up value would be counted as a real
+	// visitor's, and Go's own is discarded as a bot.
+	if f.UserAgent == "" {
+		return nil, errors.New("user agent is required: set --user-agent or UMAMI_USER_AGENT to the browser User-Agent to report")
+	}
+	return api.WithUserAgent(f.UserAgent), nil
+}
+
+func (f SendFlags) payload(websiteID, name, id string) (umami.SendPayload, error) {
+	p := umami.SendPayload{
+		Website:  websiteID,
+		Hostname: f.Hostname,
+		Language: f.Language,
+		Referrer: f.Referrer,
+		Screen:   f.Screen,
+		Title:    f.Title,
+		URL:      f.URL,
+		Name:     name,
+		ID:       id,
+	}
+	if p.Hostname == "" {
+		if u, err := url.Parse(f.URL); err == nil {
+			p.Hostname = u.Hostname()
+		}
+	}
+
+	data, err := parseData(f.Data)
+	if err != nil {
+		return p, err
+	}
+	p.Data = data
+
+	if f.Timestamp != "" {
+		loc := time.UTC
+		if f.Timezone != "" {
+			var err error
+			if loc, err = time.LoadLocation(f.Timezone); err != nil {
+				return p, fmt.Errorf("invalid timezone %q: %w", f.Timezone, err)
+			}
+		}
+		t, err := parseTime(f.Timestamp, loc, false)
+		if err != nil {
+			return p, fmt.Errorf("invalid --timestamp: %w", err)
+		}
+		p.Timestamp = t.Unix()
+	}
+	return p, nil
+}
+
+// parseData reads key=value pairs. Values that are JSON numbers or
+// booleans keep their type; everything else is sent as a string.
+func parseData(pairs []string) (map[string]any, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	data := make(map[string]any, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid data %q: use key=value", pair)
+		}
+		var v any
+		if err := json.Unmarshal([]byte(value), &v); err == nil {
+			switch v.(type) {
+			case float64, bool:
+				data[key] = v
+				continue
+			}
+		}
+		data[key] = value
+	}
+	return data, nil
+}
+
+func readEvents(name, eventType string, defaults umami.SendPayload) ([]umami.SendEvent, error) {
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var events []umami.SendEvent
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var probe struct {
+			Payload json.RawMessage `json:"payload"`
+		}
+		if err := json.Unmarshal([]byte(text), &probe); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		event := umami.SendEvent{Type: eventType}
+		target := any(&event.Payload)
+		if probe.Payload != nil {
+			target = &event
+		}
+		if err := json.Unmarshal([]byte(text), target); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		fillPayload(&event.Payload, defaults)
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, errors.New("no events found in batch input")
+	}
+	return events, nil
+}
+
+// fillPayload copies fields from defaults that p leaves empty. Data keys
+// are merged, with p's values taking precedence.
+func fillPayload(p *umami.SendPayload, defaults umami.SendPayload) {
+	for _, f := range []struct{ dst, src *string }{
+		{&p.Website, &defaults.Website},
+		{&p.Hostname, &defaults.Hostname},
+		{&p.Language, &defaults.Language},
+		{&p.Referrer, &defaults.Referrer},
+		{&p.Screen, &defaults.Screen},
+		{&p.Title, &defaults.Title},
+		{&p.URL, &defaults.URL},
+		{&p.Name, &defaults.Name},
+		{&p.ID, &defaults.ID},
+	} {
+		if *f.dst == "" {
+			*f.dst = *f.src
+		}
+	}
+	if p.Timestamp == 0 {
+		p.Timestamp = defaults.Timestamp
+	}
+	for k, v := range defaults.Data {
+		if p.Data == nil {
+			p.Data = map[string]any{}
+		}
+		if _, ok := p.Data[k]; !ok {
+			p.Data[k] = v
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+type SessionsCmd struct {
+	List       SessionsListCmd       `cmd:"" help:"List sessions for a website"`
+	Get        SessionsGetCmd        `cmd:"" help:"Show a session"`
+	Activity   SessionsActivityCmd   `cmd:"" help:"Pageviews and events in a session"`
+	Properties SessionsPropertiesCmd `cmd:"" help:"Custom data attached to a session"`
+	Stats      SessionsStatsCmd      `cmd:"" help:"Session summary stats"`
+}
+
+type SessionsListCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Pagination
+	Filters
+}
+
+func (c *SessionsListCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+	startAt, endAt, err := c.TimeRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	q := buildQuery(startAt, endAt, "", c.Timezone, c.Filters, 0, 0, "").Values()
+	res, err := api.Sessions.List(context.Background(), c.WebsiteID, q, c.params())
+	if err != nil {
+		return err
+	}
+	return printList(ctx, res, sessionsTable(res.Data), "No sessions found.")
+}
+
+type SessionsGetCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	SessionID string `arg:"" name:"session-id" help:"Session ID"`
+}
+
+func (c *SessionsGetCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	session, err := api.Sessions.Get(context.Background(), c.WebsiteID, c.SessionID)
+	if err != nil {
+		return err
+	}
+
+	t := out.NewTable("Field", "Value")
+	t.Row("id", session.ID)
+	t.Row("distinct id", session.DistinctID)
+	t.Row("browser", session.Browser)
+	t.Row("os", session.OS)
+	t.Row("device", session.Device)
+	t.Row("screen", session.Screen)
+	t.Row("language", session.Language)
+	t.Row("country", session.Country)
+	t.Row("region", session.Region)
+	t.Row("city", session.City)
+	t.Row("first seen", formatTime(session.FirstAt))
+	t.Row("last seen", formatTime(session.LastAt))
+	t.Row("visits", session.Visits)
+	t.Row("views", session.Views)
+	t.Row("events", session.Events)
+	t.Row("total time", session.TotalTime)
+	return ctx.Print(session, t)
+}
+
+type SessionsActivityCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	SessionID string `arg:"" name:"session-id" help:"Session ID"`
+	TimeRange
+}
+
+func (c *SessionsActivityCmd) Run(ctx *Context) error {
+	startAt, endAt, err := c.TimeRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	q := buildQuery(startAt, endAt, "", c.Timezone, Filters{}, 0, 0, "").Values()
+	activity, err := api.Sessions.Activity(context.Background(), c.WebsiteID, c.SessionID, q)
+	if err != nil {
+		return err
+	}
+
+	if len(activity) == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No activity found for session %s.\n", c.SessionID)
+		return nil
+	}
+
+	t := out.NewTable("Time", "Type", "Path", "Event", "Referrer", "Visit ID")
+	for _, a := range activity {
+		kind := "pageview"
+		if a.EventType == umami.EventTypeCustomEvent {
+			kind = "event"
+		}
+		t.Row(formatTime(a.CreatedAt), kind, a.URLPath, a.EventName, a.ReferrerDomain, a.VisitID)
+	}
+	return ctx.Print(activity, t)
+}
+
+type SessionsPropertiesCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	SessionID string `arg:"" name:"session-id" help:"Session ID"`
+}
+
+func (c *SessionsPropertiesCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	props, err := api.Sessions.Properties(context.Background(), c.WebsiteID, c.SessionID)
+	if err != nil {
+		return err
+	}
+
+	if len(props) == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No properties found for session %s.\n", c.SessionID)
+		return nil
+	}
+
+	t := out.NewTable("Key", "Value", "Recorded")
+	for _, p := range props {
+		t.Row(p.DataKey, propertyValue(p), formatTime(p.CreatedAt))
+	}
+	return ctx.Print(props, t)
+}
+
+type SessionsStatsCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Filters
+}
+
+func (c *SessionsStatsCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+	startAt, endAt, err := c.TimeRange.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	q := buildQuery(startAt, endAt, "", c.Timezone, c.Filters, 0, 0, "").Values()
+	stats, err := api.Sessions.Stats(context.Background(), c.WebsiteID, q)
+	if err != nil {
+		return err
+	}
+
+	t := out.NewTable("Metric", "Value")
+	t.Row("pageviews", stats.Pageviews.Value)
+	t.Row("visitors", stats.Visitors.Value)
+	t.Row("visits", stats.Visits.Value)
+	t.Row("countries", stats.Countries.Value)
+	t.Row("events", stats.Events.Value)
+	return ctx.Print(stats, t)
+}
+
+func sessionsTable(sessions []umami.Session) *out.Table {
+	t := out.NewTable("ID", "Browser", "OS", "Device", "Country", "City", "Visits", "Views", "Last seen")
+	for _, s := range sessions {
+		t.Row(s.ID, s.Browser, s.OS, s.Device, s.Country, s.City, s.Visits, s.Views, formatTime(s.LastAt))
+	}
+	return t
+}
+
+func propertyValue(p umami.SessionProperty) string {
+	switch {
+	case p.NumberValue != nil:
+		return strconv.FormatFloat(*p.NumberValue, 'f', -1, 64)
+	case p.DateValue != nil:
+		return formatTime(*p.DateValue)
+	}
+	return p.StringValue
+}
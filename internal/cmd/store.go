@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/internal/store"
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+type StoreCmd struct {
+	Sync  StoreSyncCmd  `cmd:"" help:"Fetch stats and metrics into the local snapshot store"`
+	Query StoreQueryCmd `cmd:"" help:"Read stored snapshots without calling the API"`
+}
+
+type StoreFlags struct {
+	StoreDir string `help:"Snapshot store directory (default: umami-cli/store in the user config directory)" env:"UMAMI_STORE_DIR" placeholder:"DIR"`
+}
+
+type StoreSyncCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Daily bool     `help:"Store one snapshot per calendar day, fetching only days not stored yet (default range: last 30 days)"`
+	Types []string `name:"type" sep:"none" help:"Also store metrics of this type (repeatable; ${metric_types})"`
+	Limit int      `help:"Rows stored per metric type (server default if unset)"`
+	Force bool     `help:"Refetch snapshots that are already stored"`
+	Filters
+	StoreFlags
+}
+
+func (c *StoreSyncCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+	for _, metricType := range c.Types {
+		if err := validateMetricType(metricType); err != nil {
+			return err
+		}
+	}
+	r := c.TimeRange
+	if c.Daily && !r.set() {
+		r.Since = "30d"
+	}
+	startAt, endAt, err := r.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+	loc, err := r.location()
+	if err != nil {
+		return err
+	}
+
+	periods := []period{{time.UnixMilli(startAt), time.UnixMilli(endAt)}}
+	if c.Daily {
+		periods = days(periods[0].StartAt.In(loc), periods[0].EndAt.In(loc))
+	}
+
+	st, err := store.Open(c.StoreDir)
+	if err != nil {
+		return err
+	}
+	existing, err := st.Snapshots(c.WebsiteID)
+	if err != nil {
+		return err
+	}
+	complete := map[string]bool{}
+	for _, snap := range existing {
+		if snap.Complete() {
+			complete[snap.Key()] = true
+		}
+	}
+
+	// Snapshots are marked complete once their range has settled, so they must
+	// come from the server rather than a cached response.
+	uncached := *ctx
+	uncached.Cache = false
+	api, err := uncached.API()
+	if err != nil {
+		return err
+	}
+
+	kinds := []string{""}
+	kinds = append(kinds, c.Types...)
+	filters := c.Filters.values()
+	now := time.Now()
+
+	var fetched []store.Snapshot
+	t := out.NewTable("Start", "End", "Kind", "Status")
+	for _, p := range periods {
+		if p.StartAt.After(now) {
+			continue
+		}
+		for _, metricType := range kinds {
+			snap := store.Snapshot{
+				Kind:     store.KindStats,
+				Type:     metricType,
+				StartAt:  p.StartAt.UTC(),
+				EndAt:    p.EndAt.UTC(),
+				Timezone: r.timezone(),
+				Filters:  filters,
+			}
+			if metricType != "" {
+				snap.Kind = store.KindMetrics
+			}
+			kind := snapshotKind(snap)
+
+			if !c.Force && complete[snap.Key()] {
+				t.Row(formatDate(p.StartAt), formatDate(p.EndAt), kind, "stored")
+				continue
+			}
+			if err := c.fetch(api, &snap); err != nil {
+				// Keep what was fetched so a rerun resumes from here.
+				if saveErr := st.Put(c.WebsiteID, fetched...); saveErr != nil {
+					return saveErr
+				}
+				return err
+			}
+			fetched = append(fetched, snap)
+
+			status := "fetched"
+			if !snap.Complete() {
+				status = "fetched (partial)"
+			}
+			t.Row(formatDate(p.StartAt), formatDate(p.EndAt), kind, status)
+		}
+	}
+
+	if err := st.Put(c.WebsiteID, fetched...); err != nil {
+		return err
+	}
+	return ctx.Print(fetched, t)
+}
+
+func (c *StoreSyncCmd) fetch(api *umami.Client, snap *store.Snapshot) error {
+	q := buildQuery(snap.StartAt.UnixMilli(), snap.EndAt.UnixMilli(), "", snap.Timezone, c.Filters, 0, 0, "")
+	snap.FetchedAt = time.Now().UTC()
+	if snap.Kind == store.KindStats {
+		stats, err := api.Analytics.Stats(context.Background(), c.WebsiteID, q)
+		if err != nil {
+			return err
+		}
+		snap.Stats = &stats.StatsValues
+		return nil
+	}
+
+	q = buildQuery(snap.StartAt.UnixMilli(), snap.EndAt.UnixMilli(), "", snap.Timezone, c.Filters, c.Limit, 0, snap.Type)
+	metrics, err := api.Analytics.Metrics(context.Background(), c.WebsiteID, q)
+	if err != nil {
+		return err
+	}
+	snap.Metrics = metrics
+	return nil
+}
+
+type StoreQueryCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	TimeRange
+	Type string `help:"Show stored metrics of this type instead of stats"`
+	Filters
+	StoreFlags
+}
+
+// Run lists stored snapshots that lie within the range (all of them when
+// no range is given) and were taken with the same timezone and filters.
+func (c *StoreQueryCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+	if c.Type != "" {
+		if err := validateMetricType(c.Type); err != nil {
+			return err
+		}
+	}
+
+	var startAt, endAt time.Time
+	if c.TimeRange.set() {
+		start, end, err := c.TimeRange.resolve(time.Now())
+		if err != nil {
+			return err
+		}
+		startAt, endAt = time.UnixMilli(start), time.UnixMilli(end)
+	}
+
+	st, err := store.Open(c.StoreDir)
+	if err != nil {
+		return err
+	}
+	snapshots, err := st.Snapshots(c.WebsiteID)
+	if err != nil {
+		return err
+	}
+
+	matched := c.match(snapshots, startAt, endAt)
+	if len(matched) == 0 && ctx.Output == out.FormatTable {
+		out.Printf("No snapshots found in %s.\n", st.Dir())
+		return nil
+	}
+
+	var t *out.Table
+	if c.Type == "" {
+		t = out.NewTable("Start", "End", "Pageviews", "Visitors", "Visits", "Bounces", "Total time", "Complete")
+		for _, snap := range matched {
+			s := snap.Stats
+			if s == nil {
+				s = &umami.StatsValues{}
+			}
+			t.Row(formatDate(snap.StartAt), formatDate(snap.EndAt), s.Pageviews, s.Visitors, s.Visits, s.Bounces, s.TotalTime, yesNo(snap.Complete()))
+		}
+	} else {
+		t = out.NewTable("Start", "End", c.Type, "Count")
+		for _, snap := range matched {
+			for _, m := range snap.Metrics {
+				t.Row(formatDate(snap.StartAt), formatDate(snap.EndAt), m.X, m.Y)
+			}
+		}
+	}
+	return ctx.Print(matched, t)
+}
+
+// match returns the snapshots of the queried kind, timezone and filters
+// that lie within [startAt, endAt], or all of them when startAt is zero.
+// Snapshots store the effective timezone, so an unset --timezone matches
+// those synced in the local zone.
+func (c *StoreQueryCmd) match(snapshots []store.Snapshot, startAt, endAt time.Time) []store.Snapshot {
+	kind := store.KindStats
+	if c.Type != "" {
+		kind = store.KindMetrics
+	}
+	timezone := c.TimeRange.timezone()
+	filters := store.FiltersKey(c.Filters.values())
+
+	var matched []store.Snapshot
+	for _, snap := range snapshots {
+		if snap.Kind != kind || snap.Type != c.Type || snap.Timezone != timezone || store.FiltersKey(snap.Filters) != filters {
+			continue
+		}
+		if !startAt.IsZero() && (snap.StartAt.Before(startAt) || snap.EndAt.After(endAt)) {
+			continue
+		}
+		matched = append(matched, snap)
+	}
+	return matched
+}
+
+// days splits [start, end] into calendar days in start's location. Each
+// day covers midnight to the last millisecond before the next midnight.
+func days(start, end time.Time) []period {
+	var periods []period
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for !day.After(end) {
+		next := day.AddDate(0, 0, 1)
+		periods = append(periods, period{day, next.Add(-time.Millisecond)})
+		day = next
+	}
+	return periods
+}
+
+func snapshotKind(snap store.Snapshot) string {
+	if snap.Kind == store.KindMetrics {
+		return "metrics:" + snap.Type
+	}
+	return snap.Kind
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/yborunov/umami-cli/internal/config"
+	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/internal/store"
+)
+
+func TestStoreQueryMatchesSyncTimezone(t *testing.T) {
+	t.Setenv("TZ", "Etc/UTC")
+	var timezones []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timezones = append(timezones, r.URL.Query().Get("timezone"))
+		w.Write([]byte(`{"pageviews":10,"visitors":4,"visits":5,"bounces":1,"totaltime":60}`))
+	}))
+	defer srv.Close()
+
+	ctx := &Context{Config: &config.Config{Endpoint: srv.URL + "/api", Token: "token"}, Output: out.FormatJSON}
+	dir := t.TempDir()
+	rng := TimeRange{
+		StartAt: strconv.FormatInt(utcDate(2024, 1, 1).UnixMilli(), 10),
+		EndAt:   strconv.FormatInt(endOf(utcDate(2024, 1, 1)).UnixMilli(), 10),
+	}
+	sync := &StoreSyncCmd{WebsiteID: "w1", TimeRange: rng, StoreFlags: StoreFlags{StoreDir: dir}}
+	if err := sync.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(timezones) != 1 || timezones[0] != "Etc/UTC" {
+		t.Fatalf("sync requested timezones %v, want [Etc/UTC]", timezones)
+	}
+
+	st, err := store.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshots, err := st.Snapshots("w1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Timezone != "Etc/UTC" {
+		t.Fatalf("got snapshots %+v, want one in Etc/UTC", snapshots)
+	}
+
+	tests := []struct {
+		timezone string
+		want     int
+	}{
+		{"", 1},
+		{"Etc/UTC", 1},
+		{"Europe/Berlin", 0},
+	}
+	for _, tt := range tests {
+		query := &StoreQueryCmd{WebsiteID: "w1", TimeRange: TimeRange{Timezone: tt.timezone}}
+		if got := query.match(snapshots, utcDate(2024, 1, 1), endOf(utcDate(2024, 1, 1))); len(got) != tt.want {
+			t.Errorf("--timezone %q: matched %d snapshots, want %d", tt.timezone, len(got), tt.want)
+		}
+	}
+}
@@ -4,85 +4,308 @@ import (
 	"context"
 	"errors"
 
-	"github.com/yborunov/umami-cli/internal/client"
 	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
 )
 
 type TeamsCmd struct {
 	List     TeamsListCmd     `cmd:"" help:"List teams"`
-	Websites TeamsWebsitesCmd `cmd:"" help:"List websites for a team"`
+	Get      TeamsGetCmd      `cmd:"" help:"Show a team"`
+	Create   TeamsCreateCmd   `cmd:"" help:"Create a team"`
+	Update   TeamsUpdateCmd   `cmd:"" help:"Update a team"`
+	Delete   TeamsDeleteCmd   `cmd:"" help:"Delete a team"`
+	Join     TeamsJoinCmd     `cmd:"" help:"Join a team with an access code"`
+	Users    TeamsUsersCmd    `cmd:"" help:"Manage team members"`
+	Websites TeamsWebsitesCmd `cmd:"" help:"List and move team websites"`
 }
 
-type TeamsListCmd struct{}
+type TeamsListCmd struct {
+	Pagination
+}
 
-type Team struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+func (c *TeamsListCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Teams.List(context.Background(), c.params())
+	if err != nil {
+		return err
+	}
+	return printList(ctx, res, teamsTable(res.Data), "No teams found.")
 }
 
-type teamsListResponse struct {
-	Data []Team `json:"data"`
+type TeamsGetCmd struct {
+	TeamID string `arg:"" name:"team-id" help:"Team ID"`
 }
 
-func (c *TeamsListCmd) Run(ctx *Context) error {
-	api, err := client.New(ctx.Config.Endpoint, ctx.Config.Token)
+func (c *TeamsGetCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	team, err := api.Teams.Get(context.Background(), c.TeamID)
+	if err != nil {
+		return err
+	}
+	return ctx.Print(team, teamsTable([]umami.Team{*team}))
+}
+
+type TeamsCreateCmd struct {
+	Name string `help:"Team name" required:""`
+}
+
+func (c *TeamsCreateCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	team, err := api.Teams.Create(context.Background(), c.Name)
+	if err != nil {
+		return err
+	}
+	return ctx.Print(team, teamsTable([]umami.Team{*team}))
+}
+
+type TeamsUpdateCmd struct {
+	TeamID     string `arg:"" name:"team-id" help:"Team ID"`
+	Name       string `help:"New team name"`
+	AccessCode string `help:"New access code"`
+}
+
+func (c *TeamsUpdateCmd) Run(ctx *Context) error {
+	if c.Name == "" && c.AccessCode == "" {
+		return errors.New("nothing to update: set --name or --access-code")
+	}
+
+	api, err := ctx.API()
 	if err != nil {
 		return err
 	}
 
-	var resp teamsListResponse
-	_, err = api.Do(context.Background(), "GET", "/teams", nil, &resp, true)
+	team, err := api.Teams.Update(context.Background(), c.TeamID, umami.TeamParams{
+		Name:       c.Name,
+		AccessCode: c.AccessCode,
+	})
 	if err != nil {
 		return err
 	}
+	return ctx.Print(team, teamsTable([]umami.Team{*team}))
+}
+
+type TeamsDeleteCmd struct {
+	TeamID string `arg:"" name:"team-id" help:"Team ID"`
+	Yes    bool   `short:"y" help:"Skip confirmation prompt"`
+}
 
-	if ctx.JSON {
-		return out.PrintJSON(resp.Data)
+func (c *TeamsDeleteCmd) Run(ctx *Context) error {
+	if err := confirm(c.Yes, "Delete team %s?", c.TeamID); err != nil {
+		return err
 	}
 
-	if len(resp.Data) == 0 {
-		out.Printf("No teams found.\n")
-		return nil
+	api, err := ctx.API()
+	if err != nil {
+		return err
 	}
 
-	for _, t := range resp.Data {
-		out.Printf("%s\t%s\n", t.ID, t.Name)
+	if err := api.Teams.Delete(context.Background(), c.TeamID); err != nil {
+		return err
 	}
+	out.Printf("Team %s deleted.\n", c.TeamID)
+	return nil
+}
+
+type TeamsJoinCmd struct {
+	AccessCode string `arg:"" name:"access-code" help:"Team access code"`
+}
+
+func (c *TeamsJoinCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	team, err := api.Teams.Join(context.Background(), c.AccessCode)
+	if err != nil {
+		return err
+	}
+	return ctx.Print(team, teamsTable([]umami.Team{*team}))
+}
+
+type TeamsUsersCmd struct {
+	List       TeamsUsersListCmd       `cmd:"" help:"List team members"`
+	Add        TeamsUsersAddCmd        `cmd:"" help:"Add a user to a team"`
+	UpdateRole TeamsUsersUpdateRoleCmd `cmd:"" help:"Change a member's role"`
+	Remove     TeamsUsersRemoveCmd     `cmd:"" help:"Remove a user from a team"`
+}
+
+type TeamsUsersListCmd struct {
+	TeamID string `arg:"" name:"team-id" help:"Team ID"`
+	Pagination
+}
+
+func (c *TeamsUsersListCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Teams.Users(context.Background(), c.TeamID, c.params())
+	if err != nil {
+		return err
+	}
+	return printList(ctx, res, teamUsersTable(res.Data), "No members found for team "+c.TeamID+".")
+}
+
+type TeamsUsersAddCmd struct {
+	TeamID string `arg:"" name:"team-id" help:"Team ID"`
+	UserID string `help:"User ID to add" required:""`
+	Role   string `help:"Team role (team-owner|team-manager|team-member|team-view-only)" enum:"team-owner,team-manager,team-member,team-view-only" default:"team-member"`
+}
+
+func (c *TeamsUsersAddCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	user, err := api.Teams.AddUser(context.Background(), c.TeamID, c.UserID, c.Role)
+	if err != nil {
+		return err
+	}
+	if ctx.Output.Structured() {
+		return ctx.Print(user, nil)
+	}
+	out.Printf("Added user %s to team %s as %s.\n", c.UserID, c.TeamID, c.Role)
+	return nil
+}
+
+type TeamsUsersUpdateRoleCmd struct {
+	TeamID string `arg:"" name:"team-id" help:"Team ID"`
+	UserID string `arg:"" name:"user-id" help:"User ID"`
+	Role   string `help:"Team role (team-owner|team-manager|team-member|team-view-only)" enum:"team-owner,team-manager,team-member,team-view-only" required:""`
+}
+
+func (c *TeamsUsersUpdateRoleCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	if err := api.Teams.UpdateUserRole(context.Background(), c.TeamID, c.UserID, c.Role); err != nil {
+		return err
+	}
+	out.Printf("User %s in team %s is now %s.\n", c.UserID, c.TeamID, c.Role)
+	return nil
+}
+
+type TeamsUsersRemoveCmd struct {
+	TeamID string `arg:"" name:"team-id" help:"Team ID"`
+	UserID string `arg:"" name:"user-id" help:"User ID"`
+	Yes    bool   `short:"y" help:"Skip confirmation prompt"`
+}
+
+func (c *TeamsUsersRemoveCmd) Run(ctx *Context) error {
+	if err := confirm(c.Yes, "Remove user %s from team %s?", c.UserID, c.TeamID); err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	if err := api.Teams.RemoveUser(context.Background(), c.TeamID, c.UserID); err != nil {
+		return err
+	}
+	out.Printf("User %s removed from team %s.\n", c.UserID, c.TeamID)
 	return nil
 }
 
 type TeamsWebsitesCmd struct {
+	List   TeamsWebsitesListCmd   `cmd:"" default:"withargs" help:"List websites for a team"`
+	Add    TeamsWebsitesAddCmd    `cmd:"" help:"Move websites into a team"`
+	Remove TeamsWebsitesRemoveCmd `cmd:"" help:"Remove a website from a team"`
+}
+
+type TeamsWebsitesListCmd struct {
 	TeamID string `arg:"" name:"team-id" help:"Team ID"`
+	Pagination
 }
 
-func (c *TeamsWebsitesCmd) Run(ctx *Context) error {
+func (c *TeamsWebsitesListCmd) Run(ctx *Context) error {
 	if c.TeamID == "" {
 		return errors.New("team-id is required")
 	}
 
-	api, err := client.New(ctx.Config.Endpoint, ctx.Config.Token)
+	api, err := ctx.API()
 	if err != nil {
 		return err
 	}
 
-	var resp websitesListResponse
-	path := "/teams/" + c.TeamID + "/websites"
-	_, err = api.Do(context.Background(), "GET", path, nil, &resp, true)
+	res, err := api.Teams.Websites(context.Background(), c.TeamID, c.params())
 	if err != nil {
 		return err
 	}
+	return printList(ctx, res, websitesTable(res.Data), "No websites found for team "+c.TeamID+".")
+}
+
+type TeamsWebsitesAddCmd struct {
+	TeamID     string   `arg:"" name:"team-id" help:"Team ID"`
+	WebsiteIDs []string `arg:"" name:"website-id" help:"Website IDs to move into the team"`
+}
 
-	if ctx.JSON {
-		return out.PrintJSON(resp.Data)
+func (c *TeamsWebsitesAddCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
 	}
 
-	if len(resp.Data) == 0 {
-		out.Printf("No websites found for team %s.\n", c.TeamID)
-		return nil
+	if err := api.Teams.AddWebsites(context.Background(), c.TeamID, c.WebsiteIDs...); err != nil {
+		return err
 	}
+	out.Printf("Added %d website(s) to team %s.\n", len(c.WebsiteIDs), c.TeamID)
+	return nil
+}
 
-	for _, w := range resp.Data {
-		out.Printf("%s\t%s\t%s\n", w.ID, w.Name, w.Domain)
+type TeamsWebsitesRemoveCmd struct {
+	TeamID    string `arg:"" name:"team-id" help:"Team ID"`
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	Yes       bool   `short:"y" help:"Skip confirmation prompt"`
+}
+
+func (c *TeamsWebsitesRemoveCmd) Run(ctx *Context) error {
+	if err := confirm(c.Yes, "Remove website %s from team %s?", c.WebsiteID, c.TeamID); err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	if err := api.Teams.RemoveWebsite(context.Background(), c.TeamID, c.WebsiteID); err != nil {
+		return err
 	}
+	out.Printf("Website %s removed from team %s.\n", c.WebsiteID, c.TeamID)
 	return nil
 }
+
+func teamsTable(teams []umami.Team) *out.Table {
+	t := out.NewTable("ID", "Name", "Access code", "Created")
+	for _, team := range teams {
+		t.Row(team.ID, team.Name, team.AccessCode, formatDate(team.CreatedAt))
+	}
+	return t
+}
+
+func teamUsersTable(users []umami.TeamUser) *out.Table {
+	t := out.NewTable("User ID", "Username", "Role", "Joined")
+	for _, u := range users {
+		t.Row(u.UserID, u.User.Username, u.Role, formatDate(u.CreatedAt))
+	}
+	return t
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TimeRange struct {
+	StartAt  string `help:"Start time (ms since epoch, RFC3339, YYYY-MM-DD or YYYYMMDD)"`
+	EndAt    string `help:"End time (ms since epoch, RFC3339, YYYY-MM-DD or YYYYMMDD; dates are inclusive)"`
+	Since    string `help:"Relative start ending now (e.g. 90m, 12h, 7d, 2w)"`
+	Range    string `help:"Named range (today|yesterday|this-week|last-week|this-month|last-month|this-year|last-year|ytd)"`
+	Timezone string `help:"Timezone (e.g. America/Los_Angeles; defaults to the local timezone)"`
+}
+
+var localDateLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// resolve returns the range as millisecond epoch bounds. With no flags set
+// it covers the 24 hours before now.
+func (r TimeRange) resolve(now time.Time) (int64, int64, error) {
+	loc, err := r.location()
+	if err != nil {
+		return 0, 0, err
+	}
+	now = now.In(loc)
+
+	set := 0
+	for _, v := range []string{r.Since, r.Range, r.StartAt + r.EndAt} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return 0, 0, errors.New("use only one of --since, --range or --start-at/--end-at")
+	}
+
+	switch {
+	case r.Since != "":
+		d, err := parseSince(r.Since)
+		if err != nil {
+			return 0, 0, err
+		}
+		return now.Add(-d).UnixMilli(), now.UnixMilli(), nil
+	case r.Range != "":
+		start, end, err := namedRange(r.Range, now)
+		if err != nil {
+			return 0, 0, err
+		}
+		return start.UnixMilli(), end.UnixMilli(), nil
+	case r.StartAt != "" || r.EndAt != "":
+		if r.StartAt == "" || r.EndAt == "" {
+			return 0, 0, errors.New("both --start-at and --end-at are required")
+		}
+		start, err := parseTime(r.StartAt, loc, false)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid --start-at: %w", err)
+		}
+		end, err := parseTime(r.EndAt, loc, true)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid --end-at: %w", err)
+		}
+		if !end.After(start) {
+			return 0, 0, errors.New("--end-at must be after --start-at")
+		}
+		return start.UnixMilli(), end.UnixMilli(), nil
+	}
+
+	return now.Add(-24 * time.Hour).UnixMilli(), now.UnixMilli(), nil
+}
+
+// set reports whether any range flag was given.
+func (r TimeRange) set() bool {
+	return r.Since != "" || r.Range != "" || r.StartAt != "" || r.EndAt != ""
+}
+
+func (r TimeRange) location() (*time.Location, error) {
+	if r.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", r.Timezone, err)
+	}
+	return loc, nil
+}
+
+// timezone returns the zone sent to the server, so that it buckets results
+// in the same zone the range was resolved in.
+func (r TimeRange) timezone() string {
+	if r.Timezone != "" {
+		return r.Timezone
+	}
+	return localTimezone()
+}
+
+// localTimezone returns the IANA name of the local timezone, taken from TZ
+// or the /etc/localtime link, or "" when it cannot be determined.
+func localTimezone() string {
+	if tz, ok := os.LookupEnv("TZ"); ok {
+		tz = strings.TrimPrefix(tz, ":")
+		if tz == "" {
+			return "UTC"
+		}
+		if _, name, ok := strings.Cut(tz, "zoneinfo/"); ok {
+			tz = name
+		}
+		if _, err := time.LoadLocation(tz); err != nil {
+			return ""
+		}
+		return tz
+	}
+	target, err := filepath.EvalSymlinks("/etc/localtime")
+	if err != nil {
+		return ""
+	}
+	if _, name, ok := strings.Cut(target, "zoneinfo/"); ok {
+		return name
+	}
+	return ""
+}
+
+// parseTime accepts epoch milliseconds, RFC3339 timestamps, local date-times
+// and plain dates (YYYY-MM-DD or YYYYMMDD). A plain date used as an end
+// bound covers the whole day.
+func parseTime(value string, loc *time.Location, end bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	// Eight digits are a compact date; as epoch milliseconds they would be
+	// a moment on 1 January 1970.
+	if _, err := strconv.Atoi(value); err == nil && len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q (use YYYYMMDD)", value)
+		}
+		return dateBound(t, end), nil
+	}
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(ms).In(loc), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.In(loc), nil
+	}
+	for _, layout := range localDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return dateBound(t, end), nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q (use ms since epoch, RFC3339 or YYYY-MM-DD)", value)
+}
+
+func dateBound(day time.Time, end bool) time.Time {
+	if end {
+		return day.AddDate(0, 0, 1).Add(-time.Millisecond)
+	}
+	return day
+}
+
+func parseSince(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+	for suffix, unit := range units {
+		if n, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil || count <= 0 {
+				return 0, fmt.Errorf("invalid --since %q", value)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --since %q (e.g. 90m, 12h, 7d, 2w)", value)
+	}
+	return d, nil
+}
+
+// namedRange returns calendar-aligned bounds in now's location. Ranges that
+// include the current period end at the close of that period.
+func namedRange(name string, now time.Time) (time.Time, time.Time, error) {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	week := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	year := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+	last := func(t time.Time) time.Time { return t.Add(-time.Millisecond) }
+
+	switch strings.ToLower(name) {
+	case "today":
+		return day, last(day.AddDate(0, 0, 1)), nil
+	case "yesterday":
+		return day.AddDate(0, 0, -1), last(day), nil
+	case "this-week":
+		return week, last(week.AddDate(0, 0, 7)), nil
+	case "last-week":
+		return week.AddDate(0, 0, -7), last(week), nil
+	case "this-month":
+		return month, last(month.AddDate(0, 1, 0)), nil
+	case "last-month":
+		return month.AddDate(0, -1, 0), last(month), nil
+	case "this-year":
+		return year, last(year.AddDate(1, 0, 0)), nil
+	case "last-year":
+		return year.AddDate(-1, 0, 0), last(year), nil
+	case "ytd":
+		return year, now, nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("unknown range %q (today|yesterday|this-week|last-week|this-month|last-month|this-year|last-year|ytd)", name)
+}
+
+// comparison returns the period compared against [startAt, endAt]: the
+// explicit --compare-start/--compare-end bounds when given, otherwise the
+// previous period of equal length (prev) or the same dates a year earlier
+// (yoy).
+func (r TimeRange) comparison(startAt, endAt int64, mode, compareStart, compareEnd string) (int64, int64, error) {
+	loc, err := r.location()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if compareStart != "" || compareEnd != "" {
+		if compareStart == "" || compareEnd == "" {
+			return 0, 0, errors.New("both --compare-start and --compare-end are required")
+		}
+		start, err := parseTime(compareStart, loc, false)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid --compare-start: %w", err)
+		}
+		end, err := parseTime(compareEnd, loc, true)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid --compare-end: %w", err)
+		}
+		if !end.After(start) {
+			return 0, 0, errors.New("--compare-end must be after --compare-start")
+		}
+		return start.UnixMilli(), end.UnixMilli(), nil
+	}
+
+	start, end := time.UnixMilli(startAt).In(loc), time.UnixMilli(endAt).In(loc)
+	switch mode {
+	case "", "prev":
+		d := end.Sub(start)
+		return start.Add(-d).UnixMilli(), startAt - 1, nil
+	case "yoy":
+		return start.AddDate(-1, 0, 0).UnixMilli(), end.AddDate(-1, 0, 0).UnixMilli(), nil
+	}
+	return 0, 0, fmt.Errorf("unknown comparison %q (prev|yoy)", mode)
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// testNow is Wednesday 13 March 2024, 15:04:05 UTC.
+var testNow = time.Date(2024, 3, 13, 15, 4, 5, 0, time.UTC)
+
+func utcDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func endOf(day time.Time) time.Time {
+	return day.AddDate(0, 0, 1).Add(-time.Millisecond)
+}
+
+func TestParseTime(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	tests := []struct {
+		value string
+		loc   *time.Location
+		end   bool
+		want  time.Time
+	}{
+		{"1704067200000", time.UTC, false, utcDate(2024, 1, 1)},
+		{"2024-01-31T12:00:00Z", time.UTC, false, time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)},
+		{"2024-01-31T12:00:00+01:00", time.UTC, false, time.Date(2024, 1, 31, 11, 0, 0, 0, time.UTC)},
+		{"2024-01-31 12:00", berlin, false, time.Date(2024, 1, 31, 12, 0, 0, 0, berlin)},
+		{"2024-01-31T12:00:30", time.UTC, false, time.Date(2024, 1, 31, 12, 0, 30, 0, time.UTC)},
+		{"2024-01-31", time.UTC, false, utcDate(2024, 1, 31)},
+		{"2024-01-31", time.UTC, true, endOf(utcDate(2024, 1, 31))},
+		{"2024-01-31", berlin, false, time.Date(2024, 1, 31, 0, 0, 0, 0, berlin)},
+		{"20240131", time.UTC, false, utcDate(2024, 1, 31)},
+		{"20240131", time.UTC, true, endOf(utcDate(2024, 1, 31))},
+		{" 2024-01-31 ", time.UTC, false, utcDate(2024, 1, 31)},
+	}
+	for _, tt := range tests {
+		got, err := parseTime(tt.value, tt.loc, tt.end)
+		if err != nil {
+			t.Errorf("parseTime(%q): %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseTime(%q, end=%t) = %s, want %s", tt.value, tt.end, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "yesterday", "2024-13-01", "20241301", "31/01/2024"} {
+		if _, err := parseTime(value, time.UTC, false); err == nil {
+			t.Errorf("parseTime(%q) succeeded, want error", value)
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"90m", 90 * time.Minute},
+		{"12h", 12 * time.Hour},
+		{"7d", 7 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.value)
+		if err != nil {
+			t.Errorf("parseSince(%q): %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSince(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "0d", "-2d", "xd", "7", "-1h"} {
+		if _, err := parseSince(value); err == nil {
+			t.Errorf("parseSince(%q) succeeded, want error", value)
+		}
+	}
+}
+
+func TestNamedRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end time.Time
+	}{
+		{"today", utcDate(2024, 3, 13), endOf(utcDate(2024, 3, 13))},
+		{"yesterday", utcDate(2024, 3, 12), endOf(utcDate(2024, 3, 12))},
+		{"this-week", utcDate(2024, 3, 11), endOf(utcDate(2024, 3, 17))},
+		{"last-week", utcDate(2024, 3, 4), endOf(utcDate(2024, 3, 10))},
+		{"this-month", utcDate(2024, 3, 1), endOf(utcDate(2024, 3, 31))},
+		{"last-month", utcDate(2024, 2, 1), endOf(utcDate(2024, 2, 29))},
+		{"this-year", utcDate(2024, 1, 1), endOf(utcDate(2024, 12, 31))},
+		{"last-year", utcDate(2023, 1, 1), endOf(utcDate(2023, 12, 31))},
+		{"ytd", utcDate(2024, 1, 1), testNow},
+		{"Last-Month", utcDate(2024, 2, 1), endOf(utcDate(2024, 2, 29))},
+	}
+	for _, tt := range tests {
+		start, end, err := namedRange(tt.name, testNow)
+		if err != nil {
+			t.Errorf("namedRange(%q): %v", tt.name, err)
+			continue
+		}
+		if !start.Equal(tt.start) || !end.Equal(tt.end) {
+			t.Errorf("namedRange(%q) = %s - %s, want %s - %s", tt.name, start, end, tt.start, tt.end)
+		}
+	}
+
+	if _, _, err := namedRange("last-decade", testNow); err == nil {
+		t.Error("namedRange(last-decade) succeeded, want error")
+	}
+}
+
+func TestTimeRangeResolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		r          TimeRange
+		start, end time.Time
+	}{
+		{"default", TimeRange{Timezone: "UTC"}, testNow.Add(-24 * time.Hour), testNow},
+		{"since", TimeRange{Since: "7d", Timezone: "UTC"}, testNow.AddDate(0, 0, -7), testNow},
+		{"range", TimeRange{Range: "yesterday", Timezone: "UTC"}, utcDate(2024, 3, 12), endOf(utcDate(2024, 3, 12))},
+		{"dates", TimeRange{StartAt: "2024-01-01", EndAt: "20240131", Timezone: "UTC"}, utcDate(2024, 1, 1), endOf(utcDate(2024, 1, 31))},
+		{"epoch", TimeRange{StartAt: "1704067200000", EndAt: "1704153600000", Timezone: "UTC"}, utcDate(2024, 1, 1), utcDate(2024, 1, 2)},
+	}
+	for _, tt := range tests {
+		start, end, err := tt.r.resolve(testNow)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if start != tt.start.UnixMilli() || end != tt.end.UnixMilli() {
+			t.Errorf("%s: got %s - %s, want %s - %s", tt.name,
+				time.UnixMilli(start).UTC(), time.UnixMilli(end).UTC(), tt.start, tt.end)
+		}
+	}
+
+	invalid := map[string]TimeRange{
+		"since and range":  {Since: "7d", Range: "today"},
+		"start only":       {StartAt: "2024-01-01"},
+		"end before start": {StartAt: "2024-02-01", EndAt: "2024-01-01"},
+		"bad timezone":     {Timezone: "Mars/Olympus"},
+		"bad range":        {Range: "soon"},
+	}
+	for name, r := range invalid {
+		if _, _, err := r.resolve(testNow); err == nil {
+			t.Errorf("%s: resolve succeeded, want error", name)
+		}
+	}
+}
+
+func TestLocalTimezone(t *testing.T) {
+	tests := map[string]string{
+		"Europe/Berlin":                  "Europe/Berlin",
+		":America/New_York":              "America/New_York",
+		"/usr/share/zoneinfo/Asia/Tokyo": "Asia/Tokyo",
+		"":                               "UTC",
+		"Mars/Olympus":                   "",
+	}
+	for tz, want := range tests {
+		t.Setenv("TZ", tz)
+		if got := localTimezone(); got != want {
+			t.Errorf("TZ=%q: localTimezone() = %q, want %q", tz, got, want)
+		}
+	}
+}
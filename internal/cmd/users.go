@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+type UsersCmd struct {
+	List     UsersListCmd     `cmd:"" help:"List users"`
+	Get      UsersGetCmd      `cmd:"" help:"Show a user"`
+	Create   UsersCreateCmd   `cmd:"" help:"Create a user"`
+	Update   UsersUpdateCmd   `cmd:"" help:"Update a user"`
+	Delete   UsersDeleteCmd   `cmd:"" help:"Delete a user"`
+	Websites UsersWebsitesCmd `cmd:"" help:"List websites owned by a user"`
+	Teams    UsersTeamsCmd    `cmd:"" help:"List teams a user belongs to"`
+}
+
+type UsersListCmd struct {
+	Pagination
+}
+
+func (c *UsersListCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Users.List(context.Background(), c.params())
+	if err != nil {
+		return err
+	}
+	return printList(ctx, res, usersTable(res.Data), "No users found.")
+}
+
+type UsersGetCmd struct {
+	UserID string `arg:"" name:"user-id" help:"User ID"`
+}
+
+func (c *UsersGetCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	user, err := api.Users.Get(context.Background(), c.UserID)
+	if err != nil {
+		return err
+	}
+	return ctx.Print(user, usersTable([]umami.User{*user}))
+}
+
+type UsersCreateCmd struct {
+	Username string `help:"Username" required:""`
+	Password string `help:"Password" required:""`
+	Role     string `help:"Role (admin|user|view-only)" enum:"admin,user,view-only" default:"user"`
+}
+
+func (c *UsersCreateCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	user, err := api.Users.Create(context.Background(), umami.UserParams{
+		Username: c.Username,
+		Password: c.Password,
+		Role:     c.Role,
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.Print(user, usersTable([]umami.User{*user}))
+}
+
+type UsersUpdateCmd struct {
+	UserID   string `arg:"" name:"user-id" help:"User ID"`
+	Username string `help:"New username"`
+	Password string `help:"New password"`
+	Role     string `help:"New role (admin|user|view-only)"`
+}
+
+func (c *UsersUpdateCmd) Run(ctx *Context) error {
+	if c.Username == "" && c.Password == "" && c.Role == "" {
+		return errors.New("nothing to update: set --username, --password or --role")
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	user, err := api.Users.Update(context.Background(), c.UserID, umami.UserParams{
+		Username: c.Username,
+		Password: c.Password,
+		Role:     c.Role,
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.Print(user, usersTable([]umami.User{*user}))
+}
+
+type UsersDeleteCmd struct {
+	UserID string `arg:"" name:"user-id" help:"User ID"`
+	Yes    bool   `short:"y" help:"Skip confirmation prompt"`
+}
+
+func (c *UsersDeleteCmd) Run(ctx *Context) error {
+	if err := confirm(c.Yes, "Delete user %s?", c.UserID); err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	if err := api.Users.Delete(context.Background(), c.UserID); err != nil {
+		return err
+	}
+	out.Printf("User %s deleted.\n", c.UserID)
+	return nil
+}
+
+type UsersWebsitesCmd struct {
+	UserID string `arg:"" name:"user-id" help:"User ID"`
+	Pagination
+}
+
+func (c *UsersWebsitesCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Users.Websites(context.Background(), c.UserID, c.params())
+	if err != nil {
+		return err
+	}
+	return printList(ctx, res, websitesTable(res.Data), "No websites found for user "+c.UserID+".")
+}
+
+type UsersTeamsCmd struct {
+	UserID string `arg:"" name:"user-id" help:"User ID"`
+	Pagination
+}
+
+func (c *UsersTeamsCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Users.Teams(context.Background(), c.UserID, c.params())
+	if err != nil {
+		return err
+	}
+	return printList(ctx, res, teamsTable(res.Data), "No teams found for user "+c.UserID+".")
+}
+
+type MeCmd struct {
+	Show     MeShowCmd     `cmd:"" default:"1" help:"Show the current user"`
+	Websites MeWebsitesCmd `cmd:"" help:"List your websites"`
+	Teams    MeTeamsCmd    `cmd:"" help:"List your teams"`
+}
+
+type MeShowCmd struct{}
+
+func (c *MeShowCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	user, err := api.Me.Get(context.Background())
+	if err != nil {
+		return err
+	}
+	return ctx.Print(user, usersTable([]umami.User{*user}))
+}
+
+type MeWebsitesCmd struct {
+	Pagination
+}
+
+func (c *MeWebsitesCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Me.Websites(context.Background(), c.params())
+	if err != nil {
+		return err
+	}
+	return printList(ctx, res, websitesTable(res.Data), "No websites found.")
+}
+
+type MeTeamsCmd struct {
+	Pagination
+}
+
+func (c *MeTeamsCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Me.Teams(context.Background(), c.params())
+	if err != nil {
+		return err
+	}
+	return printList(ctx, res, teamsTable(res.Data), "No teams found.")
+}
+
+func usersTable(users []umami.User) *out.Table {
+	t := out.NewTable("ID", "Username", "Role", "Created")
+	for _, u := range users {
+		t.Row(u.ID, u.Username, u.Role, formatDate(u.CreatedAt))
+	}
+	return t
+}
@@ -2,82 +2,191 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"errors"
+	"time"
 
-	"github.com/yborunov/umami-cli/internal/client"
 	"github.com/yborunov/umami-cli/internal/out"
+	"github.com/yborunov/umami-cli/pkg/umami"
 )
 
 type WebsitesCmd struct {
-	List WebsitesListCmd `cmd:"" help:"List websites"`
+	List   WebsitesListCmd   `cmd:"" help:"List websites"`
+	Get    WebsitesGetCmd    `cmd:"" help:"Show a website"`
+	Create WebsitesCreateCmd `cmd:"" help:"Create a website"`
+	Update WebsitesUpdateCmd `cmd:"" help:"Update a website"`
+	Delete WebsitesDeleteCmd `cmd:"" help:"Delete a website and its data"`
+	Reset  WebsitesResetCmd  `cmd:"" help:"Delete all collected data for a website"`
 }
 
-type WebsitesListCmd struct{}
+type WebsitesListCmd struct {
+	Pagination
+}
 
-type Website struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	Domain string `json:"domain"`
+func (c *WebsitesListCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	res, err := api.Websites.List(context.Background(), c.params())
+	if err != nil {
+		return err
+	}
+	return printList(ctx, res, websitesTable(res.Data), "No websites found.")
 }
 
-type websitesListResponse struct {
-	Data []Website `json:"data"`
+type WebsitesGetCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
 }
 
-func (c *WebsitesListCmd) Run(ctx *Context) error {
-	api, err := client.New(ctx.Config.Endpoint, ctx.Config.Token)
+func (c *WebsitesGetCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	website, err := api.Websites.Get(context.Background(), c.WebsiteID)
+	if err != nil {
+		return err
+	}
+	return ctx.Print(website, websitesTable([]umami.Website{*website}))
+}
+
+type WebsitesCreateCmd struct {
+	Name    string `help:"Website name" required:""`
+	Domain  string `help:"Website domain (e.g. example.com)" required:""`
+	ShareID string `help:"Share ID for the public dashboard"`
+	TeamID  string `help:"Team that owns the website"`
+}
+
+func (c *WebsitesCreateCmd) Run(ctx *Context) error {
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	website, err := api.Websites.Create(context.Background(), umami.WebsiteParams{
+		Name:    c.Name,
+		Domain:  c.Domain,
+		ShareID: c.ShareID,
+		TeamID:  c.TeamID,
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.Print(website, websitesTable([]umami.Website{*website}))
+}
+
+type WebsitesUpdateCmd struct {
+	WebsiteID    string `arg:"" name:"website-id" help:"Website ID"`
+	Name         string `help:"New website name"`
+	Domain       string `help:"New website domain"`
+	ShareID      string `help:"New share ID"`
+	ClearShareID bool   `help:"Remove the share ID, disabling the public dashboard"`
+	TeamID       string `help:"Move the website to this team"`
+}
+
+func (c *WebsitesUpdateCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+	if c.Name == "" && c.Domain == "" && c.ShareID == "" && !c.ClearShareID && c.TeamID == "" {
+		return errors.New("nothing to update: set --name, --domain, --share-id, --clear-share-id or --team-id")
+	}
+	if c.ShareID != "" && c.ClearShareID {
+		return errors.New("use only one of --share-id or --clear-share-id")
+	}
+
+	api, err := ctx.API()
+	if err != nil {
+		return err
+	}
+
+	website, err := api.Websites.Update(context.Background(), c.WebsiteID, umami.WebsiteParams{
+		Name:         c.Name,
+		Domain:       c.Domain,
+		ShareID:      c.ShareID,
+		ClearShareID: c.ClearShareID,
+		TeamID:       c.TeamID,
+	})
 	if err != nil {
 		return err
 	}
+	return ctx.Print(website, websitesTable([]umami.Website{*website}))
+}
+
+type WebsitesDeleteCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	Yes       bool   `short:"y" help:"Skip confirmation prompt"`
+}
 
-	var resp websitesListResponse
-	if debugEnabled() {
-		out.Printf("debug: websites request method=GET path=/websites endpoint=%s\n", ctx.Config.Endpoint)
-		status, body, err := api.DoRaw(context.Background(), "GET", "/websites", nil, true)
-		if err != nil {
-			out.Printf("debug: websites response status=%d error=%v\n", status, err)
-			if len(body) > 0 {
-				out.Printf("debug: websites response body=%s\n", truncateBody(body))
-			}
-			return err
-		}
-		out.Printf("debug: websites response status=%d\n", status)
-		if len(body) > 0 {
-			out.Printf("debug: websites response body=%s\n", truncateBody(body))
-		}
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return fmt.Errorf("failed to parse websites response: %w", err)
-		}
-	} else {
-		_, err = api.Do(context.Background(), "GET", "/websites", nil, &resp, true)
-		if err != nil {
-			return err
-		}
+func (c *WebsitesDeleteCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
 	}
+	if err := confirm(c.Yes, "Delete website %s and all of its data?", c.WebsiteID); err != nil {
+		return err
+	}
+
+	api, err := ctx.API()
 	if err != nil {
 		return err
 	}
 
-	if ctx.JSON {
-		return out.PrintJSON(resp.Data)
+	if err := api.Websites.Delete(context.Background(), c.WebsiteID); err != nil {
+		return err
 	}
+	out.Printf("Website %s deleted.\n", c.WebsiteID)
+	return nil
+}
+
+type WebsitesResetCmd struct {
+	WebsiteID string `arg:"" name:"website-id" help:"Website ID"`
+	Yes       bool   `short:"y" help:"Skip confirmation prompt"`
+}
 
-	if len(resp.Data) == 0 {
-		out.Printf("No websites found.\n")
-		return nil
+func (c *WebsitesResetCmd) Run(ctx *Context) error {
+	if err := validateWebsiteID(c.WebsiteID); err != nil {
+		return err
+	}
+	if err := confirm(c.Yes, "Delete all collected data for website %s?", c.WebsiteID); err != nil {
+		return err
 	}
 
-	for _, w := range resp.Data {
-		out.Printf("%s\t%s\t%s\n", w.ID, w.Name, w.Domain)
+	api, err := ctx.API()
+	if err != nil {
+		return err
 	}
+
+	if err := api.Websites.Reset(context.Background(), c.WebsiteID); err != nil {
+		return err
+	}
+	out.Printf("Website %s reset.\n", c.WebsiteID)
 	return nil
 }
 
-func truncateBody(body []byte) string {
-	const max = 2048
-	if len(body) <= max {
-		return string(body)
+func websitesTable(websites []umami.Website) *out.Table {
+	t := out.NewTable("ID", "Name", "Domain", "Share ID", "Team ID", "Created")
+	for _, w := range websites {
+		t.Row(w.ID, w.Name, w.Domain, w.ShareID, w.TeamID, formatDate(w.CreatedAt))
+	}
+	return t
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
 	}
-	return string(body[:max]) + "...(truncated)"
+	return t.Local().Format("2006-01-02 15:04:05")
 }
@@ -6,36 +6,141 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
+const DefaultProfile = "default"
+
+type Profile struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
 type Config struct {
+	Current  string              `json:"current,omitempty"`
+	Profiles map[string]*Profile `json:"profiles"`
+
+	// Resolved settings for the active profile after flag and env overrides.
+	Profile  string `json:"-"`
+	Endpoint string `json:"-"`
+	Token    string `json:"-"`
+
+	// explicit is set when the profile was chosen by flag or env.
+	explicit bool
+}
+
+// legacyConfig is the single-profile layout written by earlier versions.
+type legacyConfig struct {
 	Endpoint string `json:"endpoint"`
 	Token    string `json:"token"`
 }
 
-func Load(flagEndpoint, flagToken string) (*Config, error) {
-	cfg := &Config{}
+func Load(flagProfile, flagEndpoint, flagToken string) (*Config, error) {
+	cfg := &Config{Profiles: map[string]*Profile{}}
 	if err := cfg.read(); err != nil {
 		return nil, err
 	}
 
+	cfg.Profile = flagProfile
+	cfg.explicit = flagProfile != ""
+	if cfg.Profile == "" {
+		cfg.Profile = cfg.Current
+	}
+	if cfg.Profile == "" {
+		cfg.Profile = DefaultProfile
+	}
+	if p, ok := cfg.Profiles[cfg.Profile]; ok {
+		cfg.Endpoint = p.Endpoint
+		cfg.Token = p.Token
+	}
+
 	if flagEndpoint != "" {
 		cfg.Endpoint = flagEndpoint
 	}
 	if flagToken != "" {
 		cfg.Token = flagToken
 	}
-
-	if cfg.Endpoint == "" {
-		return nil, errors.New("missing endpoint: set --endpoint or UMAMI_URL")
+	if cfg.Endpoint != "" {
+		cfg.Endpoint = normalizeEndpoint(cfg.Endpoint)
 	}
-
-	cfg.Endpoint = normalizeEndpoint(cfg.Endpoint)
 	return cfg, nil
 }
 
+// RequireEndpoint reports an error when no endpoint is configured for the
+// active profile. An unknown profile is only an error here, so that the
+// config commands can still create it.
+func (c *Config) RequireEndpoint() error {
+	if _, ok := c.Profiles[c.Profile]; !ok && c.explicit && c.Endpoint == "" {
+		return fmt.Errorf("unknown profile %q: run `umami config profiles add %s --url <url>`", c.Profile, c.Profile)
+	}
+	if c.Endpoint == "" {
+		return errors.New("missing endpoint: set --endpoint or UMAMI_URL, or add a profile with `umami config profiles add`")
+	}
+	return nil
+}
+
+// Save stores the active endpoint and token in the active profile.
 func (c *Config) Save() error {
+	p := c.Profiles[c.Profile]
+	if p == nil {
+		p = &Profile{}
+		c.Profiles[c.Profile] = p
+	}
+	p.Endpoint = c.Endpoint
+	p.Token = c.Token
+	if c.Current == "" {
+		c.Current = c.Profile
+	}
+	return c.write()
+}
+
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *Config) AddProfile(name, endpoint, token string) error {
+	if name == "" {
+		return errors.New("profile name is required")
+	}
+	if endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	if _, ok := c.Profiles[name]; ok {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	c.Profiles[name] = &Profile{Endpoint: normalizeEndpoint(endpoint), Token: token}
+	if c.Current == "" {
+		c.Current = name
+	}
+	return c.write()
+}
+
+func (c *Config) UseProfile(name string) error {
+	if _, ok := c.Profiles[name]; !ok {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+	c.Current = name
+	return c.write()
+}
+
+func (c *Config) RemoveProfile(name string) error {
+	if _, ok := c.Profiles[name]; !ok {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+	delete(c.Profiles, name)
+	if c.Current == name {
+		c.Current = ""
+	}
+	return c.write()
+}
+
+func (c *Config) write() error {
 	path, err := path()
 	if err != nil {
 		return err
@@ -66,7 +171,23 @@ func (c *Config) read() error {
 	if err := json.Unmarshal(data, c); err != nil {
 		return fmt.Errorf("invalid config file: %w", err)
 	}
-	return nil
+	if c.Profiles == nil {
+		c.Profiles = map[string]*Profile{}
+	}
+	if len(c.Profiles) > 0 {
+		return nil
+	}
+
+	var legacy legacyConfig
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return fmt.Errorf("invalid config file: %w", err)
+	}
+	if legacy.Endpoint == "" && legacy.Token == "" {
+		return nil
+	}
+	c.Profiles[DefaultProfile] = &Profile{Endpoint: legacy.Endpoint, Token: legacy.Token}
+	c.Current = DefaultProfile
+	return c.write()
 }
 
 func path() (string, error) {
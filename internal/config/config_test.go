@@ -0,0 +1,47 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddProfileNamedByFlag(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	// UMAMI_PROFILE=prod before the prod profile exists.
+	cfg, err := Load("prod", "", "")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := cfg.RequireEndpoint(); err == nil || !strings.Contains(err.Error(), `unknown profile "prod"`) {
+		t.Errorf("RequireEndpoint: got %v, want unknown profile", err)
+	}
+	if err := cfg.AddProfile("prod", "https://analytics.example.com", "token"); err != nil {
+		t.Fatalf("AddProfile: %v", err)
+	}
+
+	cfg, err = Load("prod", "", "")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := cfg.RequireEndpoint(); err != nil {
+		t.Errorf("RequireEndpoint: %v", err)
+	}
+	if cfg.Endpoint != "https://analytics.example.com/api" || cfg.Token != "token" {
+		t.Errorf("got endpoint %q, token %q", cfg.Endpoint, cfg.Token)
+	}
+}
+
+func TestUnknownProfileWithEndpoint(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, err := Load("ci", "https://analytics.example.com", "")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := cfg.RequireEndpoint(); err != nil {
+		t.Errorf("RequireEndpoint: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package out
+
+import "os"
+
+type Color string
+
+const (
+	Green Color = "32"
+	Red   Color = "31"
+	Gray  Color = "90"
+)
+
+// Colorize wraps s in an ANSI color when stdout is a terminal and NO_COLOR
+// is unset. Colored cells should be the last column of a table, since the
+// escape codes count towards column width.
+func Colorize(s string, c Color) string {
+	if !colorEnabled() {
+		return s
+	}
+	return "\033[" + string(c) + "m" + s + "\033[0m"
+}
+
+func colorEnabled() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -2,16 +2,88 @@ package out
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
 )
 
-func PrintJSON(v any) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(v)
+type Format string
+
+const (
+	FormatTable    Format = "table"
+	FormatJSON     Format = "json"
+	FormatNDJSON   Format = "ndjson"
+	FormatCSV      Format = "csv"
+	FormatTSV      Format = "tsv"
+	FormatMarkdown Format = "markdown"
+	FormatYAML     Format = "yaml"
+	// FormatDOT is a Graphviz digraph, rendered by commands that produce
+	// graphs (reports journey and reports saved run).
+	FormatDOT Format = "dot"
+)
+
+// ErrDOTUnsupported is returned when dot output is requested from a
+// command that does not produce a graph.
+var ErrDOTUnsupported = errors.New("dot output is only supported by `reports journey` and `reports saved run`")
+
+// Structured reports whether the format serializes data directly rather
+// than rendering a table.
+func (f Format) Structured() bool {
+	return f == FormatJSON || f == FormatNDJSON || f == FormatYAML
+}
+
+// Print writes data in the given format. Structured formats encode data
+// itself; tabular formats render t.
+func Print(format Format, data any, t *Table) error {
+	return Fprint(os.Stdout, format, data, t)
+}
+
+func Fprint(w io.Writer, format Format, data any, t *Table) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, data)
+	case FormatNDJSON:
+		return writeNDJSON(w, data)
+	case FormatYAML:
+		return writeYAML(w, data)
+	case FormatTable, "":
+		return t.writeText(w)
+	case FormatCSV:
+		return t.writeDelimited(w, ',')
+	case FormatTSV:
+		return t.writeDelimited(w, '\t')
+	case FormatMarkdown:
+		return t.writeMarkdown(w)
+	case FormatDOT:
+		return ErrDOTUnsupported
+	}
+	return fmt.Errorf("unsupported output format %q", format)
 }
 
 func Printf(format string, args ...any) {
 	fmt.Printf(format, args...)
 }
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeNDJSON writes one compact JSON document per element when v is a
+// slice, and a single line otherwise.
+func writeNDJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return enc.Encode(v)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := enc.Encode(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
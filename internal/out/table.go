@@ -0,0 +1,98 @@
+package out
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Table is the tabular view of a command result, used by the table, csv,
+// tsv and markdown formats.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+func NewTable(headers ...string) *Table {
+	return &Table{Headers: headers}
+}
+
+// Row appends a row, formatting each value with fmt.Sprint.
+func (t *Table) Row(values ...any) {
+	row := make([]string, len(values))
+	for i, v := range values {
+		row[i] = fmt.Sprint(v)
+	}
+	t.Rows = append(t.Rows, row)
+}
+
+func (t *Table) writeText(w io.Writer) error {
+	if t == nil {
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(t.Headers))
+	for i, h := range t.Headers {
+		headers[i] = strings.ToUpper(h)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = flatten(cell)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (t *Table) writeDelimited(w io.Writer, comma rune) error {
+	if t == nil {
+		return nil
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if err := cw.Write(t.Headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func (t *Table) writeMarkdown(w io.Writer) error {
+	if t == nil {
+		return nil
+	}
+	line := func(cells []string) string {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = strings.ReplaceAll(flatten(cell), "|", `\|`)
+		}
+		return "| " + strings.Join(escaped, " | ") + " |"
+	}
+
+	sep := make([]string, len(t.Headers))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	if _, err := fmt.Fprintln(w, line(t.Headers)); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "| "+strings.Join(sep, " | ")+" |"); err != nil {
+		return err
+	}
+	for _, row := range t.Rows {
+		if _, err := fmt.Fprintln(w, line(row)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flatten(cell string) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(cell)
+}
@@ -0,0 +1,163 @@
+package out
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// writeYAML renders v as YAML by walking its JSON encoding, which keeps
+// struct field order and json tags without a YAML dependency.
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	node, err := decodeNode(dec)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	writeNode(buf, node, 0)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+type field struct {
+	key   string
+	value any
+}
+
+type object []field
+
+// decodeNode reads one JSON value, keeping object keys in document order.
+func decodeNode(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := object{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, field{key: key.(string), value: value})
+		}
+		_, err := dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			value, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err := dec.Token()
+		return arr, err
+	}
+	return tok, nil
+}
+
+func writeNode(buf *bytes.Buffer, node any, indent int) {
+	pad := strings.Repeat("  ", indent)
+	switch n := node.(type) {
+	case object:
+		if len(n) == 0 {
+			buf.WriteString(pad + "{}\n")
+			return
+		}
+		for _, f := range n {
+			buf.WriteString(pad + yamlString(f.key) + ":")
+			writeChild(buf, f.value, indent+1)
+		}
+	case []any:
+		if len(n) == 0 {
+			buf.WriteString(pad + "[]\n")
+			return
+		}
+		for _, item := range n {
+			buf.WriteString(pad + "-")
+			if obj, ok := item.(object); ok && len(obj) > 0 {
+				// Inline the first key after the dash, indent the rest.
+				buf.WriteString(" " + yamlString(obj[0].key) + ":")
+				writeChild(buf, obj[0].value, indent+2)
+				if len(obj) > 1 {
+					writeNode(buf, obj[1:], indent+1)
+				}
+				continue
+			}
+			writeChild(buf, item, indent+1)
+		}
+	default:
+		buf.WriteString(pad + yamlScalar(n) + "\n")
+	}
+}
+
+func writeChild(buf *bytes.Buffer, value any, indent int) {
+	switch v := value.(type) {
+	case object:
+		if len(v) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+		buf.WriteString("\n")
+		writeNode(buf, v, indent)
+	case []any:
+		if len(v) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+		buf.WriteString("\n")
+		writeNode(buf, v, indent)
+	default:
+		buf.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+func yamlScalar(v any) string {
+	switch s := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(s)
+	case json.Number:
+		return s.String()
+	case string:
+		return yamlString(s)
+	}
+	return fmt.Sprint(v)
+}
+
+// yamlString quotes strings that YAML would otherwise read as another type
+// or that contain syntax characters.
+func yamlString(s string) string {
+	if s == "" {
+		return `""`
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	if strings.ContainsAny(s, ":#{}[],&*!|>'\"%@`\n\t\\") || strings.TrimSpace(s) != s || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "?") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,240 @@
+// Package store keeps local snapshots of analytics results so history can
+// be queried without the API and beyond the server's retention. Each
+// website's snapshots are kept in one JSON file.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+// Snapshot kinds.
+const (
+	KindStats   = "stats"
+	KindMetrics = "metrics"
+)
+
+// Snapshot is the result of one stats or metrics query. Snapshots are
+// keyed by kind, metric type, range, timezone and filters; storing a
+// snapshot with an existing key replaces it.
+type Snapshot struct {
+	Kind      string             `json:"kind"`
+	Type      string             `json:"type,omitempty"`
+	StartAt   time.Time          `json:"startAt"`
+	EndAt     time.Time          `json:"endAt"`
+	Timezone  string             `json:"timezone,omitempty"`
+	Filters   map[string]string  `json:"filters,omitempty"`
+	FetchedAt time.Time          `json:"fetchedAt"`
+	Stats     *umami.StatsValues `json:"stats,omitempty"`
+	Metrics   []umami.Metric     `json:"metrics,omitempty"`
+}
+
+// Complete reports whether the snapshot was taken at least
+// umami.SettleWindow after its range ended, so refetching it would not
+// change the result.
+func (s Snapshot) Complete() bool {
+	return !s.FetchedAt.Before(s.EndAt.Add(umami.SettleWindow))
+}
+
+func (s Snapshot) Key() string {
+	return strings.Join([]string{
+		s.Kind,
+		s.Type,
+		s.StartAt.UTC().Format(time.RFC3339Nano),
+		s.EndAt.UTC().Format(time.RFC3339Nano),
+		s.Timezone,
+		FiltersKey(s.Filters),
+	}, "|")
+}
+
+// FiltersKey returns a canonical form of filters for comparison.
+func FiltersKey(filters map[string]string) string {
+	keys := make([]string, 0, len(filters))
+	for k := range filters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + filters[k]
+	}
+	return strings.Join(parts, "&")
+}
+
+type Store struct {
+	dir string
+}
+
+type websiteFile struct {
+	WebsiteID string     `json:"websiteId"`
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+// Open returns the store rooted at dir, or at DefaultDir when dir is empty.
+func Open(dir string) (*Store, error) {
+	if dir == "" {
+		var err error
+		if dir, err = DefaultDir(); err != nil {
+			return nil, err
+		}
+	}
+	return &Store{dir: dir}, nil
+}
+
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "umami-cli", "store"), nil
+}
+
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Snapshots returns every snapshot stored for a website, ordered by start
+// time. A website without a store file has none.
+func (s *Store) Snapshots(websiteID string) ([]Snapshot, error) {
+	data, err := os.ReadFile(s.path(websiteID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f websiteFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid store file %s: %w", s.path(websiteID), err)
+	}
+	return f.Snapshots, nil
+}
+
+// Put adds snapshots for a website, replacing stored snapshots with the
+// same key. The website's file is locked while it is read and rewritten,
+// so concurrent syncs keep each other's snapshots.
+func (s *Store) Put(websiteID string, snapshots ...Snapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	unlock, err := s.lock(websiteID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	existing, err := s.Snapshots(websiteID)
+	if err != nil {
+		return err
+	}
+
+	index := make(map[string]int, len(existing))
+	for i, snap := range existing {
+		index[snap.Key()] = i
+	}
+	for _, snap := range snapshots {
+		if i, ok := index[snap.Key()]; ok {
+			existing[i] = snap
+			continue
+		}
+		index[snap.Key()] = len(existing)
+		existing = append(existing, snap)
+	}
+	sort.SliceStable(existing, func(i, j int) bool {
+		return existing[i].StartAt.Before(existing[j].StartAt)
+	})
+
+	return s.write(websiteID, websiteFile{WebsiteID: websiteID, Snapshots: existing})
+}
+
+// lockTimeout is how long Put waits for another process to release a
+// website's lock. Locks older than staleLock were left behind by a process
+// that died and are removed.
+const (
+	lockTimeout = 30 * time.Second
+	staleLock   = 10 * time.Minute
+)
+
+// lock creates the website's lock file, waiting while another process
+// holds it, and returns a function that releases it.
+func (s *Store) lock(websiteID string) (func(), error) {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return nil, err
+	}
+	path := s.path(websiteID) + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			info, err := f.Stat()
+			f.Close()
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return func() { removeLock(path, info) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLock {
+			removeLock(path, info)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("store for website %s is locked by another sync (remove %s if none is running)", websiteID, path)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// removeLock removes the lock file at path only if it is still the one
+// described by info. Another process may have broken a stale lock and
+// taken a new one in the meantime, which must be left alone.
+func removeLock(path string, info os.FileInfo) {
+	current, err := os.Stat(path)
+	if err != nil || !os.SameFile(info, current) || !current.ModTime().Equal(info.ModTime()) {
+		return
+	}
+	os.Remove(path)
+}
+
+// write replaces the website file atomically so an interrupted sync never
+// leaves a truncated store.
+func (s *Store) write(websiteID string, f websiteFile) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".snapshots-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(websiteID))
+}
+
+func (s *Store) path(websiteID string) string {
+	return filepath.Join(s.dir, filepath.Base(websiteID)+".json")
+}
@@ -0,0 +1,150 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+func day(i int) Snapshot {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i)
+	return Snapshot{Kind: KindStats, StartAt: start, EndAt: start.AddDate(0, 0, 1).Add(-time.Millisecond)}
+}
+
+func TestPutReplacesByKeyAndSorts(t *testing.T) {
+	st, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := st.Put("w1", day(2), day(0)); err != nil {
+		t.Fatal(err)
+	}
+	updated := day(0)
+	updated.FetchedAt = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	if err := st.Put("w1", updated, day(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := st.Snapshots("w1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 3 {
+		t.Fatalf("got %d snapshots, want 3", len(snapshots))
+	}
+	for i, snap := range snapshots {
+		if !snap.StartAt.Equal(day(i).StartAt) {
+			t.Errorf("snapshot %d starts %s, want %s", i, snap.StartAt, day(i).StartAt)
+		}
+	}
+	if !snapshots[0].Complete() {
+		t.Error("replaced snapshot was not updated")
+	}
+}
+
+func TestPutConcurrent(t *testing.T) {
+	st, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const writers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- st.Put("w1", day(i))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshots, err := st.Snapshots("w1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != writers {
+		t.Errorf("got %d snapshots, want %d", len(snapshots), writers)
+	}
+	if _, err := os.Stat(filepath.Join(st.Dir(), "w1.json.lock")); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+}
+
+func TestPutRemovesStaleLock(t *testing.T) {
+	st, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock := filepath.Join(st.Dir(), "w1.json.lock")
+	if err := os.WriteFile(lock, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * staleLock)
+	if err := os.Chtimes(lock, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := st.Put("w1", day(0)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRemoveLockKeepsReplacedLock(t *testing.T) {
+	lock := filepath.Join(t.TempDir(), "w1.json.lock")
+	if err := os.WriteFile(lock, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * staleLock)
+	if err := os.Chtimes(lock, old, old); err != nil {
+		t.Fatal(err)
+	}
+	stale, err := os.Stat(lock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process breaks the stale lock and takes a new one.
+	if err := os.Remove(lock); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(lock, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	removeLock(lock, stale)
+	if _, err := os.Stat(lock); err != nil {
+		t.Errorf("new lock was removed: %v", err)
+	}
+}
+
+func TestCompleteWaitsForSettleWindow(t *testing.T) {
+	snap := day(0)
+	tests := []struct {
+		fetchedAt time.Time
+		want      bool
+	}{
+		{snap.EndAt.Add(-time.Hour), false},
+		{snap.EndAt.Add(time.Minute), false},
+		{snap.EndAt.Add(umami.SettleWindow - time.Minute), false},
+		{snap.EndAt.Add(umami.SettleWindow), true},
+		{snap.EndAt.AddDate(0, 1, 0), true},
+	}
+	for _, tt := range tests {
+		snap.FetchedAt = tt.fetchedAt
+		if got := snap.Complete(); got != tt.want {
+			t.Errorf("fetched %s after the end: Complete = %t, want %t", tt.fetchedAt.Sub(snap.EndAt), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package umami
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// AnalyticsService wraps the /websites/:id analytics endpoints.
+type AnalyticsService struct {
+	client *Client
+}
+
+// QueryParams selects the range, grouping and filters of an analytics
+// query. Zero fields are left out of the request.
+type QueryParams struct {
+	StartAt time.Time
+	EndAt   time.Time
+	// Unit groups series by year, month, day, hour or minute.
+	Unit string
+	// Timezone is the IANA zone used to bucket series, e.g. Europe/Berlin.
+	Timezone string
+	// Type is the metric type, required by Metrics and MetricsExpanded.
+	Type   string
+	Limit  int
+	Offset int
+	// Compare adds a comparison series to Pageviews: prev or yoy.
+	Compare string
+	// Filters maps filter names (path, referrer, country, ...) to values.
+	Filters Filters
+}
+
+// Values encodes p as query parameters, with times in epoch milliseconds.
+func (p QueryParams) Values() url.Values {
+	q := url.Values{}
+	if !p.StartAt.IsZero() {
+		q.Set("startAt", strconv.FormatInt(p.StartAt.UnixMilli(), 10))
+	}
+	if !p.EndAt.IsZero() {
+		q.Set("endAt", strconv.FormatInt(p.EndAt.UnixMilli(), 10))
+	}
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	set("unit", p.Unit)
+	set("timezone", p.Timezone)
+	set("type", p.Type)
+	set("compare", p.Compare)
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset > 0 {
+		q.Set("offset", strconv.Itoa(p.Offset))
+	}
+	for key, value := range p.Filters {
+		set(key, value)
+	}
+	return q
+}
+
+type Active struct {
+	Visitors int64 `json:"visitors"`
+}
+
+type StatsValues struct {
+	Pageviews int64 `json:"pageviews"`
+	Visitors  int64 `json:"visitors"`
+	Visits    int64 `json:"visits"`
+	Bounces   int64 `json:"bounces"`
+	TotalTime int64 `json:"totaltime"`
+}
+
+type Stats struct {
+	StatsValues
+	Comparison *StatsValues `json:"comparison,omitempty"`
+}
+
+type Metric struct {
+	X string `json:"x"`
+	Y int64  `json:"y"`
+}
+
+type ExpandedMetric struct {
+	Name      string `json:"name"`
+	Pageviews int64  `json:"pageviews"`
+	Visitors  int64  `json:"visitors"`
+	Visits    int64  `json:"visits"`
+	Bounces   int64  `json:"bounces"`
+	TotalTime int64  `json:"totaltime"`
+}
+
+type SeriesPoint struct {
+	X string `json:"x"`
+	Y int64  `json:"y"`
+}
+
+type PageviewsSeries struct {
+	Pageviews []SeriesPoint `json:"pageviews"`
+	Sessions  []SeriesPoint `json:"sessions"`
+}
+
+type Pageviews struct {
+	PageviewsSeries
+	Compare *PageviewsSeries `json:"compare,omitempty"`
+}
+
+type EventSeriesPoint struct {
+	X string `json:"x"`
+	T string `json:"t"`
+	Y int64  `json:"y"`
+}
+
+// Realtime is a snapshot of the last 30 minutes of activity. Countries,
+// URLs and Referrers map values to view counts.
+type Realtime struct {
+	Countries map[string]int64 `json:"countries"`
+	URLs      map[string]int64 `json:"urls"`
+	Referrers map[string]int64 `json:"referrers"`
+	Events    []RealtimeEvent  `json:"events"`
+	Series    struct {
+		Views    []SeriesPoint `json:"views"`
+		Visitors []SeriesPoint `json:"visitors"`
+	} `json:"series"`
+	Totals    RealtimeTotals `json:"totals"`
+	Timestamp int64          `json:"timestamp"`
+}
+
+type RealtimeEvent struct {
+	Type           string    `json:"__type"`
+	SessionID      string    `json:"sessionId"`
+	EventName      string    `json:"eventName,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	Browser        string    `json:"browser,omitempty"`
+	OS             string    `json:"os,omitempty"`
+	Device         string    `json:"device,omitempty"`
+	Country        string    `json:"country,omitempty"`
+	URLPath        string    `json:"urlPath,omitempty"`
+	ReferrerDomain string    `json:"referrerDomain,omitempty"`
+}
+
+type RealtimeTotals struct {
+	Views     int64 `json:"views"`
+	Visitors  int64 `json:"visitors"`
+	Events    int64 `json:"events"`
+	Countries int64 `json:"countries"`
+}
+
+func (s *AnalyticsService) Active(ctx context.Context, websiteID string) (*Active, error) {
+	resp := &Active{}
+	if err := s.get(ctx, websiteID, "/active", QueryParams{}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *AnalyticsService) Stats(ctx context.Context, websiteID string, params QueryParams) (*Stats, error) {
+	resp := &Stats{}
+	if err := s.get(ctx, websiteID, "/stats", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *AnalyticsService) Metrics(ctx context.Context, websiteID string, params QueryParams) ([]Metric, error) {
+	if params.Type == "" {
+		return nil, errors.New("type is required")
+	}
+
+	var resp []Metric
+	if err := s.get(ctx, websiteID, "/metrics", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *AnalyticsService) MetricsExpanded(ctx context.Context, websiteID string, params QueryParams) ([]ExpandedMetric, error) {
+	if params.Type == "" {
+		return nil, errors.New("type is required")
+	}
+
+	var resp []ExpandedMetric
+	if err := s.get(ctx, websiteID, "/metrics/expanded", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *AnalyticsService) Pageviews(ctx context.Context, websiteID string, params QueryParams) (*Pageviews, error) {
+	resp := &Pageviews{}
+	if err := s.get(ctx, websiteID, "/pageviews", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *AnalyticsService) EventsSeries(ctx context.Context, websiteID string, params QueryParams) ([]EventSeriesPoint, error) {
+	var resp []EventSeriesPoint
+	if err := s.get(ctx, websiteID, "/events/series", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *AnalyticsService) Realtime(ctx context.Context, websiteID string, params QueryParams) (*Realtime, error) {
+	if websiteID == "" {
+		return nil, errors.New("website-id is required")
+	}
+
+	resp := &Realtime{}
+	if err := s.client.get(ctx, "/realtime/"+escape(websiteID), params.Values(), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *AnalyticsService) get(ctx context.Context, websiteID, p string, params QueryParams, out any) error {
+	if websiteID == "" {
+		return errors.New("website-id is required")
+	}
+	return s.client.get(ctx, websitePath(websiteID)+p, params.Values(), out)
+}
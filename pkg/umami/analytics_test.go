@@ -0,0 +1,53 @@
+package umami
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQueryParamsValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		params QueryParams
+		want   url.Values
+	}{
+		{"zero", QueryParams{}, url.Values{}},
+		{
+			"all fields",
+			QueryParams{
+				StartAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndAt:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				Unit:     "day",
+				Timezone: "Europe/Berlin",
+				Type:     "path",
+				Limit:    10,
+				Offset:   20,
+				Compare:  "prev",
+				Filters:  Filters{"country": "DE", "path": ""},
+			},
+			url.Values{
+				"startAt":  {"1704067200000"},
+				"endAt":    {"1706745600000"},
+				"unit":     {"day"},
+				"timezone": {"Europe/Berlin"},
+				"type":     {"path"},
+				"limit":    {"10"},
+				"offset":   {"20"},
+				"compare":  {"prev"},
+				"country":  {"DE"},
+			},
+		},
+		{
+			"negative limit",
+			QueryParams{Limit: -1, Offset: -1},
+			url.Values{},
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.params.Values(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
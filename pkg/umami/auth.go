@@ -0,0 +1,44 @@
+package umami
+
+import (
+	"context"
+	"errors"
+)
+
+type AuthService struct {
+	client *Client
+}
+
+type LoginResponse struct {
+	Token string `json:"token"`
+	User  User   `json:"user"`
+}
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Login exchanges a username and password for an API token. The returned
+// token is not applied to the client; use WithToken for that.
+func (s *AuthService) Login(ctx context.Context, username, password string) (*LoginResponse, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("username and password are required")
+	}
+
+	resp := &LoginResponse{}
+	req := loginRequest{Username: username, Password: password}
+	if _, err := s.client.api.Do(ctx, "POST", "/auth/login", req, resp, false); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Verify checks the client token and returns the user it belongs to.
+func (s *AuthService) Verify(ctx context.Context) (*User, error) {
+	resp := &User{}
+	if err := s.client.post(ctx, "/auth/verify", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
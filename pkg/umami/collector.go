@@ -0,0 +1,135 @@
+package umami
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CollectorService sends tracking data to Umami's public collector
+// (/send and /batch). These endpoints do not use the API token; Umami
+// identifies visitors from the User-Agent header and client IP, so use
+// Client.WithUserAgent to set a browser-like User-Agent.
+type CollectorService struct {
+	client *Client
+}
+
+// Collector event types. Pageviews are events without a name.
+const (
+	EventTypeEvent    = "event"
+	EventTypeIdentify = "identify"
+)
+
+type SendEvent struct {
+	Type    string      `json:"type"`
+	Payload SendPayload `json:"payload"`
+}
+
+type SendPayload struct {
+	Website  string `json:"website"`
+	Hostname string `json:"hostname,omitempty"`
+	Language string `json:"language,omitempty"`
+	Referrer string `json:"referrer,omitempty"`
+	Screen   string `json:"screen,omitempty"`
+	Title    string `json:"title,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Tag      string `json:"tag,omitempty"`
+	// ID is the distinct ID linked to the session by identify events.
+	ID   string         `json:"id,omitempty"`
+	Data map[string]any `json:"data,omitempty"`
+	// Timestamp backdates the event, in seconds since the epoch.
+	Timestamp int64 `json:"timestamp,omitempty"`
+}
+
+type SendResult struct {
+	Cache     string `json:"cache,omitempty"`
+	SessionID string `json:"sessionId,omitempty"`
+	VisitID   string `json:"visitId,omitempty"`
+}
+
+// BatchResult reports what the server did with a batch. Servers that
+// answer with an empty body leave Processed and Errors at 0, since the
+// outcome is unknown.
+type BatchResult struct {
+	Size      int `json:"size"`
+	Processed int `json:"processed"`
+	Errors    int `json:"errors"`
+}
+
+// ErrBotRejected is returned when Umami discards an event because the
+// User-Agent looks like a bot.
+var ErrBotRejected = errors.New("event rejected as bot traffic (set a browser User-Agent)")
+
+func (s *CollectorService) Send(ctx context.Context, event SendEvent) (*SendResult, error) {
+	if err := validateSendEvent(event); err != nil {
+		return nil, err
+	}
+
+	body, err := s.post(ctx, "/send", event)
+	if err != nil {
+		return nil, err
+	}
+	resp := &SendResult{}
+	// Older servers answer with a bare cache token rather than JSON.
+	if json.Unmarshal(body, resp) != nil {
+		resp.Cache = string(bytes.TrimSpace(body))
+	}
+	return resp, nil
+}
+
+// Batch sends several events in one request.
+func (s *CollectorService) Batch(ctx context.Context, events []SendEvent) (*BatchResult, error) {
+	if len(events) == 0 {
+		return nil, errors.New("no events to send")
+	}
+	for _, e := range events {
+		if err := validateSendEvent(e); err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := s.post(ctx, "/batch", events)
+	if err != nil {
+		return nil, err
+	}
+	resp := &BatchResult{Size: len(events)}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, resp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+func (s *CollectorService) post(ctx context.Context, p string, body any) ([]byte, error) {
+	_, resp, err := s.client.api.DoRaw(ctx, "POST", p, body, false)
+	if err != nil {
+		return nil, err
+	}
+	if botRejected(resp) {
+		return nil, ErrBotRejected
+	}
+	return resp, nil
+}
+
+// botRejected reports whether the body is Umami's answer to bot traffic,
+// an object with a beep field.
+func botRejected(body []byte) bool {
+	var resp struct {
+		Beep json.RawMessage `json:"beep"`
+	}
+	return json.Unmarshal(body, &resp) == nil && resp.Beep != nil
+}
+
+func validateSendEvent(e SendEvent) error {
+	if e.Type == "" {
+		return errors.New("event type is required")
+	}
+	if e.Payload.Website == "" {
+		return errors.New("website is required")
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package umami
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func collectorServer(t *testing.T, body string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	api, err := New(srv.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return api
+}
+
+func TestCollectorBotRejected(t *testing.T) {
+	event := SendEvent{Type: EventTypeEvent, Payload: SendPayload{Website: "w1", URL: "/"}}
+	tests := []struct {
+		body string
+		bot  bool
+	}{
+		{`{"beep":"boop"}`, true},
+		{`{"sessionId":"s1","visitId":"v1"}`, false},
+		{`{"sessionId":"s1","data":{"note":"beep"}}`, false},
+		{`beep-token`, false},
+	}
+	for _, tt := range tests {
+		_, err := collectorServer(t, tt.body).Collector.Send(context.Background(), event)
+		if got := errors.Is(err, ErrBotRejected); got != tt.bot {
+			t.Errorf("body %s: got bot=%t (err %v), want %t", tt.body, got, err, tt.bot)
+		}
+	}
+}
+
+func TestCollectorBatchEmptyBody(t *testing.T) {
+	events := []SendEvent{
+		{Type: EventTypeEvent, Payload: SendPayload{Website: "w1", URL: "/a"}},
+		{Type: EventTypeEvent, Payload: SendPayload{Website: "w1", URL: "/b"}},
+	}
+	res, err := collectorServer(t, "").Collector.Batch(context.Background(), events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Size != 2 || res.Processed != 0 {
+		t.Errorf("got size %d, processed %d; want 2 and 0 (unknown)", res.Size, res.Processed)
+	}
+
+	res, err = collectorServer(t, `{"size":2,"processed":1,"errors":1}`).Collector.Batch(context.Background(), events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Processed != 1 || res.Errors != 1 {
+		t.Errorf("got %+v, want processed 1, errors 1", res)
+	}
+}
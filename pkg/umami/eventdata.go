@@ -0,0 +1,161 @@
+package umami
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+)
+
+// EventDataService wraps the /websites/:id/event-data endpoints, which
+// report on custom event properties.
+type EventDataService struct {
+	client *Client
+}
+
+// Data types used by Umami for event and session properties.
+const (
+	DataTypeString  = 1
+	DataTypeNumber  = 2
+	DataTypeBoolean = 3
+	DataTypeDate    = 4
+	DataTypeArray   = 5
+)
+
+func DataTypeName(dataType int) string {
+	switch dataType {
+	case DataTypeString:
+		return "string"
+	case DataTypeNumber:
+		return "number"
+	case DataTypeBoolean:
+		return "boolean"
+	case DataTypeDate:
+		return "date"
+	case DataTypeArray:
+		return "array"
+	}
+	return "unknown"
+}
+
+// DataValue is a property value as text. Umami returns values as strings,
+// numbers or booleans depending on the property type.
+type DataValue string
+
+func (v *DataValue) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = DataValue(s)
+		return nil
+	}
+	if bytes.Equal(data, []byte("null")) {
+		*v = ""
+		return nil
+	}
+	*v = DataValue(data)
+	return nil
+}
+
+type EventDataEvent struct {
+	EventName    string `json:"eventName"`
+	PropertyName string `json:"propertyName"`
+	DataType     int    `json:"dataType"`
+	Total        int64  `json:"total"`
+}
+
+type EventDataField struct {
+	PropertyName string    `json:"propertyName"`
+	DataType     int       `json:"dataType"`
+	Value        DataValue `json:"value"`
+	Total        int64     `json:"total"`
+}
+
+type EventDataValue struct {
+	Value DataValue `json:"value"`
+	Total int64     `json:"total"`
+}
+
+type EventDataProperty struct {
+	EventName    string `json:"eventName"`
+	PropertyName string `json:"propertyName"`
+	Total        int64  `json:"total"`
+}
+
+type EventDataStats struct {
+	Events     int64 `json:"events"`
+	Properties int64 `json:"properties"`
+	Records    int64 `json:"records"`
+}
+
+// Events lists event names with the properties recorded for each. Set
+// "event" in params to narrow to a single event.
+func (s *EventDataService) Events(ctx context.Context, websiteID string, params url.Values) ([]EventDataEvent, error) {
+	var resp []EventDataEvent
+	if err := s.get(ctx, websiteID, "/events", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *EventDataService) Fields(ctx context.Context, websiteID string, params url.Values) ([]EventDataField, error) {
+	var resp []EventDataField
+	if err := s.get(ctx, websiteID, "/fields", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Values returns the distinct values recorded for one property of one
+// event; params must set "event" and "propertyName".
+func (s *EventDataService) Values(ctx context.Context, websiteID string, params url.Values) ([]EventDataValue, error) {
+	if params.Get("event") == "" || params.Get("propertyName") == "" {
+		return nil, errors.New("event and property name are required")
+	}
+
+	var resp []EventDataValue
+	if err := s.get(ctx, websiteID, "/values", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *EventDataService) Properties(ctx context.Context, websiteID string, params url.Values) ([]EventDataProperty, error) {
+	var resp []EventDataProperty
+	if err := s.get(ctx, websiteID, "/properties", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Stats returns event data totals. Older servers wrap the totals in a
+// one-element array, which is unwrapped here.
+func (s *EventDataService) Stats(ctx context.Context, websiteID string, params url.Values) (*EventDataStats, error) {
+	var raw json.RawMessage
+	if err := s.get(ctx, websiteID, "/stats", params, &raw); err != nil {
+		return nil, err
+	}
+
+	resp := &EventDataStats{}
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		var list []EventDataStats
+		if err := json.Unmarshal(trimmed, &list); err != nil {
+			return nil, err
+		}
+		if len(list) > 0 {
+			*resp = list[0]
+		}
+		return resp, nil
+	}
+	if err := json.Unmarshal(raw, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *EventDataService) get(ctx context.Context, websiteID, p string, params url.Values, out any) error {
+	if websiteID == "" {
+		return errors.New("website-id is required")
+	}
+	return s.client.get(ctx, websitePath(websiteID)+"/event-data"+p, params, out)
+}
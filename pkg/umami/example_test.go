@@ -0,0 +1,44 @@
+package umami_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/yborunov/umami-cli/pkg/umami"
+)
+
+func Example() {
+	// A stand-in for an Umami server.
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/websites", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"w1","name":"Blog","domain":"blog.example.com"}],"count":1,"page":1,"pageSize":20}`)
+	})
+	mux.HandleFunc("GET /api/websites/w1/stats", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"pageviews":120,"visitors":40,"visits":55,"bounces":20,"totaltime":3600}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx := context.Background()
+	api, err := umami.New(srv.URL+"/api", "token")
+	if err != nil {
+		log.Fatal(err)
+	}
+	websites, err := api.Websites.List(ctx, umami.ListParams{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	stats, err := api.Analytics.Stats(ctx, websites.Data[0].ID, umami.QueryParams{
+		StartAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndAt:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s: %d pageviews from %d visitors\n", websites.Data[0].Name, stats.Pageviews, stats.Visitors)
+	// Output: Blog: 120 pageviews from 40 visitors
+}
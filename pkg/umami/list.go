@@ -0,0 +1,75 @@
+package umami
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/yborunov/umami-cli/internal/client"
+)
+
+// ListParams controls paging for list endpoints. With All set, every page
+// from Page onwards is fetched and merged into one result.
+type ListParams struct {
+	Page     int
+	PageSize int
+	Search   string
+	All      bool
+}
+
+// ListResult holds list items along with the server's paging metadata.
+// Count is the total number of matching items across all pages.
+type ListResult[T any] struct {
+	Data     []T `json:"data"`
+	Count    int `json:"count"`
+	Page     int `json:"page"`
+	PageSize int `json:"pageSize"`
+}
+
+// More reports whether items exist beyond those in Data.
+func (r *ListResult[T]) More() bool {
+	if r.PageSize == 0 || r.Count == 0 {
+		return false
+	}
+	return r.Page*r.PageSize < r.Count
+}
+
+// values merges the paging parameters into a copy of query.
+func (p ListParams) values(query url.Values) url.Values {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = append([]string(nil), v...)
+	}
+	if p.Page > 0 {
+		q.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.PageSize > 0 {
+		q.Set("pageSize", strconv.Itoa(p.PageSize))
+	}
+	if p.Search != "" {
+		q.Set("search", p.Search)
+	}
+	return q
+}
+
+func list[T any](ctx context.Context, c *Client, p string, query url.Values, params ListParams) (*ListResult[T], error) {
+	if !params.All {
+		resp := &ListResult[T]{}
+		if err := c.get(ctx, p, params.values(query), resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	resp := &ListResult[T]{Data: []T{}}
+	pager := client.NewPager[T](c.api, p, params.values(query))
+	for pager.Next(ctx) {
+		page := pager.Page()
+		resp.Data = append(resp.Data, page.Data...)
+		resp.Count = page.Count
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,489 @@
+package umami
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// ReportsService runs Umami's report endpoints (/reports/:type). Each
+// report takes a date range, optional filters and report-specific
+// parameters.
+type ReportsService struct {
+	client *Client
+}
+
+// Filters maps Umami filter names (path, country, device, ...) to values.
+type Filters map[string]string
+
+type DateRange struct {
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+	Timezone  string    `json:"timezone,omitempty"`
+}
+
+type reportRequest struct {
+	WebsiteID  string  `json:"websiteId"`
+	Type       string  `json:"type"`
+	Filters    Filters `json:"filters,omitempty"`
+	Parameters any     `json:"parameters"`
+}
+
+// Step types for funnel steps, goals and attribution targets.
+const (
+	StepTypePath  = "path"
+	StepTypeEvent = "event"
+)
+
+type Step struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type FunnelParams struct {
+	DateRange
+	// Window is the maximum number of minutes between the first and last
+	// step for a visitor to count as converted.
+	Window int    `json:"window"`
+	Steps  []Step `json:"steps"`
+}
+
+type FunnelStep struct {
+	Type      string  `json:"type"`
+	Value     string  `json:"value"`
+	Visitors  int64   `json:"visitors"`
+	Previous  int64   `json:"previous"`
+	Dropped   int64   `json:"dropped"`
+	Dropoff   float64 `json:"dropoff"`
+	Remaining float64 `json:"remaining"`
+}
+
+func (s *ReportsService) Funnel(ctx context.Context, websiteID string, params FunnelParams, filters Filters) ([]FunnelStep, error) {
+	if len(params.Steps) < 2 {
+		return nil, errors.New("a funnel needs at least two steps")
+	}
+
+	var resp []FunnelStep
+	if err := s.run(ctx, websiteID, "funnel", params, filters, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RetentionCohort is one cell of the retention report: of the visitors
+// first seen on Date, how many returned Day days later.
+type RetentionCohort struct {
+	Date           string  `json:"date"`
+	Day            int     `json:"day"`
+	Visitors       int64   `json:"visitors"`
+	ReturnVisitors int64   `json:"returnVisitors"`
+	Percentage     float64 `json:"percentage"`
+}
+
+func (s *ReportsService) Retention(ctx context.Context, websiteID string, dates DateRange, filters Filters) ([]RetentionCohort, error) {
+	var resp []RetentionCohort
+	if err := s.run(ctx, websiteID, "retention", dates, filters, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type JourneyParams struct {
+	DateRange
+	// Steps is the path length to analyse, between 3 and 7.
+	Steps     int    `json:"steps"`
+	StartStep string `json:"startStep,omitempty"`
+	EndStep   string `json:"endStep,omitempty"`
+}
+
+// Journey is a path through the site. Items holds one page or event per
+// step, with nil for steps the visitors did not reach.
+type Journey struct {
+	Items []*string `json:"items"`
+	Count int64     `json:"count"`
+}
+
+func (s *ReportsService) Journey(ctx context.Context, websiteID string, params JourneyParams, filters Filters) ([]Journey, error) {
+	if params.Steps < 3 || params.Steps > 7 {
+		return nil, errors.New("journey steps must be between 3 and 7")
+	}
+
+	var resp []Journey
+	if err := s.run(ctx, websiteID, "journey", params, filters, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// NamedValue is a labelled count. Report endpoints name the fields
+// differently (name/value, utm/views, x/y); all are accepted.
+type NamedValue struct {
+	Name  string `json:"name"`
+	Value int64  `json:"value"`
+}
+
+func (v *NamedValue) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name  *string `json:"name"`
+		Value *int64  `json:"value"`
+		UTM   *string `json:"utm"`
+		Views *int64  `json:"views"`
+		X     *string `json:"x"`
+		Y     *int64  `json:"y"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	v.Name = firstString(raw.Name, raw.UTM, raw.X)
+	for _, n := range []*int64{raw.Value, raw.Views, raw.Y} {
+		if n != nil {
+			v.Value = *n
+			break
+		}
+	}
+	return nil
+}
+
+// NamedValues decodes either a list of NamedValue or an object mapping
+// names to counts. Values decoded from an object are ordered by count,
+// then name, so output is stable across runs.
+type NamedValues []NamedValue
+
+func (v *NamedValues) UnmarshalJSON(data []byte) error {
+	var list []NamedValue
+	if err := json.Unmarshal(data, &list); err == nil {
+		*v = list
+		return nil
+	}
+	var counts map[string]int64
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return err
+	}
+	*v = make(NamedValues, 0, len(counts))
+	for name, count := range counts {
+		*v = append(*v, NamedValue{Name: name, Value: count})
+	}
+	sort.Slice(*v, func(i, j int) bool {
+		a, b := (*v)[i], (*v)[j]
+		if a.Value != b.Value {
+			return a.Value > b.Value
+		}
+		return a.Name < b.Name
+	})
+	return nil
+}
+
+type UTMReport struct {
+	Source   NamedValues `json:"utm_source"`
+	Medium   NamedValues `json:"utm_medium"`
+	Campaign NamedValues `json:"utm_campaign"`
+	Content  NamedValues `json:"utm_content"`
+	Term     NamedValues `json:"utm_term"`
+}
+
+func (s *ReportsService) UTM(ctx context.Context, websiteID string, dates DateRange, filters Filters) (*UTMReport, error) {
+	resp := &UTMReport{}
+	if err := s.run(ctx, websiteID, "utm", dates, filters, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Attribution models.
+const (
+	AttributionFirstClick = "firstClick"
+	AttributionLastClick  = "lastClick"
+)
+
+type AttributionParams struct {
+	DateRange
+	Model string `json:"model"`
+	// Type and Step identify the conversion goal, e.g. "event" and "signup".
+	Type string `json:"type"`
+	Step string `json:"step"`
+}
+
+type AttributionReport struct {
+	Referrer    NamedValues `json:"referrer"`
+	PaidAds     NamedValues `json:"paidAds"`
+	UTMSource   NamedValues `json:"utm_source"`
+	UTMMedium   NamedValues `json:"utm_medium"`
+	UTMCampaign NamedValues `json:"utm_campaign"`
+	UTMContent  NamedValues `json:"utm_content"`
+	UTMTerm     NamedValues `json:"utm_term"`
+	Total       struct {
+		Pageviews int64 `json:"pageviews"`
+		Visitors  int64 `json:"visitors"`
+		Visits    int64 `json:"visits"`
+	} `json:"total"`
+}
+
+func (s *ReportsService) Attribution(ctx context.Context, websiteID string, params AttributionParams, filters Filters) (*AttributionReport, error) {
+	if params.Model != AttributionFirstClick && params.Model != AttributionLastClick {
+		return nil, fmt.Errorf("invalid attribution model %q", params.Model)
+	}
+	if params.Type == "" || params.Step == "" {
+		return nil, errors.New("attribution goal is required")
+	}
+
+	resp := &AttributionReport{}
+	if err := s.run(ctx, websiteID, "attribution", params, filters, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type GoalParams struct {
+	DateRange
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// GoalResult counts the visitors who reached a goal (Num) out of all
+// visitors in the range (Total).
+type GoalResult struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Num   int64  `json:"num"`
+	Total int64  `json:"total"`
+}
+
+func (s *ReportsService) Goal(ctx context.Context, websiteID string, params GoalParams, filters Filters) (*GoalResult, error) {
+	if params.Type == "" || params.Value == "" {
+		return nil, errors.New("goal type and value are required")
+	}
+
+	resp := &GoalResult{}
+	if err := s.run(ctx, websiteID, "goal", params, filters, resp); err != nil {
+		return nil, err
+	}
+	resp.Type, resp.Value = params.Type, params.Value
+	return resp, nil
+}
+
+// Goals runs the goal report once per goal, in order.
+func (s *ReportsService) Goals(ctx context.Context, websiteID string, dates DateRange, goals []Step, filters Filters) ([]GoalResult, error) {
+	if len(goals) == 0 {
+		return nil, errors.New("at least one goal is required")
+	}
+
+	results := make([]GoalResult, 0, len(goals))
+	for _, g := range goals {
+		res, err := s.Goal(ctx, websiteID, GoalParams{DateRange: dates, Type: g.Type, Value: g.Value}, filters)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *res)
+	}
+	return results, nil
+}
+
+type RevenueParams struct {
+	DateRange
+	Unit     string `json:"unit,omitempty"`
+	Currency string `json:"currency"`
+}
+
+type Revenue struct {
+	Chart   []RevenuePoint   `json:"chart"`
+	Country []RevenueCountry `json:"country"`
+	Total   RevenueTotal     `json:"total"`
+}
+
+// RevenuePoint is the revenue for event X in the bucket starting at T.
+type RevenuePoint struct {
+	X string  `json:"x"`
+	T string  `json:"t"`
+	Y float64 `json:"y"`
+}
+
+type RevenueCountry struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+type RevenueTotal struct {
+	Sum         float64 `json:"sum"`
+	Count       int64   `json:"count"`
+	UniqueCount int64   `json:"unique_count"`
+	Average     float64 `json:"average"`
+}
+
+func (s *ReportsService) Revenue(ctx context.Context, websiteID string, params RevenueParams, filters Filters) (*Revenue, error) {
+	if params.Currency == "" {
+		return nil, errors.New("currency is required")
+	}
+
+	resp := &Revenue{}
+	if err := s.run(ctx, websiteID, "revenue", params, filters, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SavedReport is a report definition stored in Umami. Parameters holds the
+// report-specific settings as saved by the dashboard.
+type SavedReport struct {
+	ID          string          `json:"id"`
+	UserID      string          `json:"userId,omitempty"`
+	WebsiteID   string          `json:"websiteId"`
+	Type        string          `json:"type"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+// DecodeParameters unmarshals the stored parameters into v. Older servers
+// store them as a JSON-encoded string, which is unwrapped first.
+func (r *SavedReport) DecodeParameters(v any) error {
+	data := r.Parameters
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err == nil {
+		data = []byte(encoded)
+	}
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid parameters for report %s: %w", r.ID, err)
+	}
+	return nil
+}
+
+// Query returns the date range and filters the report was saved with.
+// Dashboard versions store the range either at the top level of the
+// parameters or under dateRange; ok is false when neither holds both
+// dates. Filters are stored as plain values or as objects with a value
+// field; other shapes are ignored.
+func (r *SavedReport) Query() (dates DateRange, filters Filters, ok bool, err error) {
+	var stored struct {
+		DateRange
+		Nested  *DateRange                 `json:"dateRange"`
+		Filters map[string]json.RawMessage `json:"filters"`
+	}
+	if err := r.DecodeParameters(&stored); err != nil {
+		return DateRange{}, nil, false, err
+	}
+
+	dates = stored.DateRange
+	if stored.Nested != nil && !stored.Nested.StartDate.IsZero() {
+		dates = *stored.Nested
+	}
+	ok = !dates.StartDate.IsZero() && !dates.EndDate.IsZero()
+
+	for name, raw := range stored.Filters {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			var object struct {
+				Value string `json:"value"`
+			}
+			if json.Unmarshal(raw, &object) != nil {
+				continue
+			}
+			value = object.Value
+		}
+		if value == "" {
+			continue
+		}
+		if filters == nil {
+			filters = Filters{}
+		}
+		filters[name] = value
+	}
+	return dates, filters, ok, nil
+}
+
+// SavedReportParams holds the fields sent when creating or updating a
+// saved report. Umami expects all of them on update.
+type SavedReportParams struct {
+	WebsiteID   string          `json:"websiteId"`
+	Type        string          `json:"type"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// List returns saved reports, optionally limited to one website.
+func (s *ReportsService) List(ctx context.Context, websiteID string, params ListParams) (*ListResult[SavedReport], error) {
+	q := url.Values{}
+	if websiteID != "" {
+		q.Set("websiteId", websiteID)
+	}
+	return list[SavedReport](ctx, s.client, "/reports", q, params)
+}
+
+func (s *ReportsService) Get(ctx context.Context, reportID string) (*SavedReport, error) {
+	if reportID == "" {
+		return nil, errors.New("report-id is required")
+	}
+
+	resp := &SavedReport{}
+	if err := s.client.get(ctx, reportPath(reportID), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *ReportsService) Create(ctx context.Context, params SavedReportParams) (*SavedReport, error) {
+	if params.WebsiteID == "" || params.Type == "" || params.Name == "" {
+		return nil, errors.New("website, type and name are required")
+	}
+
+	resp := &SavedReport{}
+	if err := s.client.post(ctx, "/reports", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *ReportsService) Update(ctx context.Context, reportID string, params SavedReportParams) (*SavedReport, error) {
+	if reportID == "" {
+		return nil, errors.New("report-id is required")
+	}
+
+	resp := &SavedReport{}
+	if err := s.client.post(ctx, reportPath(reportID), params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *ReportsService) Delete(ctx context.Context, reportID string) error {
+	if reportID == "" {
+		return errors.New("report-id is required")
+	}
+	return s.client.delete(ctx, reportPath(reportID))
+}
+
+func reportPath(reportID string) string {
+	return "/reports/" + escape(reportID)
+}
+
+func (s *ReportsService) run(ctx context.Context, websiteID, reportType string, params any, filters Filters, out any) error {
+	if websiteID == "" {
+		return errors.New("website-id is required")
+	}
+
+	req := reportRequest{
+		WebsiteID:  websiteID,
+		Type:       reportType,
+		Filters:    filters,
+		Parameters: params,
+	}
+	return s.client.post(ctx, "/reports/"+reportType, req, out)
+}
+
+func firstString(values ...*string) string {
+	for _, v := range values {
+		if v != nil {
+			return *v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package umami
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNamedValuesUnmarshal(t *testing.T) {
+	tests := []struct {
+		input string
+		want  NamedValues
+	}{
+		{
+			`{"newsletter": 3, "google": 5, "bing": 3, "ads": 3}`,
+			NamedValues{{"google", 5}, {"ads", 3}, {"bing", 3}, {"newsletter", 3}},
+		},
+		{
+			`[{"utm": "b", "views": 1}, {"name": "a", "value": 2}]`,
+			NamedValues{{"b", 1}, {"a", 2}},
+		},
+	}
+	for _, tt := range tests {
+		// Map iteration order is random; decode repeatedly to catch
+		// unstable ordering of ties.
+		for range 20 {
+			var got NamedValues
+			if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+				t.Fatalf("%s: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("%s: got %v, want %v", tt.input, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSavedReportQuery(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 30, 23, 59, 59, 0, time.UTC)
+	tests := []struct {
+		name       string
+		parameters string
+		dates      DateRange
+		filters    Filters
+		ok         bool
+	}{
+		{
+			"nested range and object filters",
+			`{"dateRange": {"startDate": "2024-01-01T00:00:00Z", "endDate": "2024-01-30T23:59:59Z", "value": "30day"},
+			  "filters": {"country": {"name": "country", "value": "DE"}, "os": "Linux", "device": {"value": ""}, "tag": 3}}`,
+			DateRange{StartDate: start, EndDate: end},
+			Filters{"country": "DE", "os": "Linux"},
+			true,
+		},
+		{
+			"top-level range",
+			`{"startDate": "2024-01-01T00:00:00Z", "endDate": "2024-01-30T23:59:59Z", "timezone": "Europe/Berlin"}`,
+			DateRange{StartDate: start, EndDate: end, Timezone: "Europe/Berlin"},
+			nil,
+			true,
+		},
+		{
+			"string-encoded parameters",
+			`"{\"startDate\": \"2024-01-01T00:00:00Z\", \"endDate\": \"2024-01-30T23:59:59Z\"}"`,
+			DateRange{StartDate: start, EndDate: end},
+			nil,
+			true,
+		},
+		{"no range", `{"window": 30}`, DateRange{}, nil, false},
+		{"start only", `{"startDate": "2024-01-01T00:00:00Z"}`, DateRange{StartDate: start}, nil, false},
+	}
+	for _, tt := range tests {
+		r := &SavedReport{ID: "r1", Parameters: json.RawMessage(tt.parameters)}
+		dates, filters, ok, err := r.Query()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if ok != tt.ok || !dates.StartDate.Equal(tt.dates.StartDate) || !dates.EndDate.Equal(tt.dates.EndDate) || dates.Timezone != tt.dates.Timezone {
+			t.Errorf("%s: got %+v ok=%t, want %+v ok=%t", tt.name, dates, ok, tt.dates, tt.ok)
+		}
+		if !reflect.DeepEqual(filters, tt.filters) {
+			t.Errorf("%s: got filters %v, want %v", tt.name, filters, tt.filters)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package umami
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+type SessionsService struct {
+	client *Client
+}
+
+type Session struct {
+	ID         string    `json:"id"`
+	WebsiteID  string    `json:"websiteId"`
+	DistinctID string    `json:"distinctId,omitempty"`
+	Hostname   string    `json:"hostname,omitempty"`
+	Browser    string    `json:"browser"`
+	OS         string    `json:"os"`
+	Device     string    `json:"device"`
+	Screen     string    `json:"screen"`
+	Language   string    `json:"language"`
+	Country    string    `json:"country"`
+	Region     string    `json:"region"`
+	City       string    `json:"city"`
+	FirstAt    time.Time `json:"firstAt"`
+	LastAt     time.Time `json:"lastAt"`
+	Visits     int64     `json:"visits"`
+	Views      int64     `json:"views"`
+	Events     int64     `json:"events,omitempty"`
+	TotalTime  int64     `json:"totaltime,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type SessionActivity struct {
+	CreatedAt      time.Time `json:"createdAt"`
+	URLPath        string    `json:"urlPath"`
+	URLQuery       string    `json:"urlQuery"`
+	ReferrerDomain string    `json:"referrerDomain"`
+	EventID        string    `json:"eventId"`
+	EventType      int       `json:"eventType"`
+	EventName      string    `json:"eventName"`
+	VisitID        string    `json:"visitId"`
+}
+
+// Event types used in SessionActivity.EventType.
+const (
+	EventTypePageview    = 1
+	EventTypeCustomEvent = 2
+)
+
+type SessionProperty struct {
+	DataKey     string     `json:"dataKey"`
+	DataType    int        `json:"dataType"`
+	StringValue string     `json:"stringValue,omitempty"`
+	NumberValue *float64   `json:"numberValue,omitempty"`
+	DateValue   *time.Time `json:"dateValue,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+type StatValue struct {
+	Value int64 `json:"value"`
+}
+
+type SessionStats struct {
+	Pageviews StatValue `json:"pageviews"`
+	Visitors  StatValue `json:"visitors"`
+	Visits    StatValue `json:"visits"`
+	Countries StatValue `json:"countries"`
+	Events    StatValue `json:"events"`
+}
+
+// List returns sessions for a website. params carries the time range and
+// filters; paging comes from paging.
+func (s *SessionsService) List(ctx context.Context, websiteID string, params url.Values, paging ListParams) (*ListResult[Session], error) {
+	if websiteID == "" {
+		return nil, errors.New("website-id is required")
+	}
+	return list[Session](ctx, s.client, websitePath(websiteID)+"/sessions", params, paging)
+}
+
+func (s *SessionsService) Get(ctx context.Context, websiteID, sessionID string) (*Session, error) {
+	if websiteID == "" || sessionID == "" {
+		return nil, errors.New("website-id and session-id are required")
+	}
+
+	resp := &Session{}
+	if err := s.client.get(ctx, sessionPath(websiteID, sessionID), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *SessionsService) Activity(ctx context.Context, websiteID, sessionID string, params url.Values) ([]SessionActivity, error) {
+	if websiteID == "" || sessionID == "" {
+		return nil, errors.New("website-id and session-id are required")
+	}
+
+	var resp []SessionActivity
+	if err := s.client.get(ctx, sessionPath(websiteID, sessionID)+"/activity", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *SessionsService) Properties(ctx context.Context, websiteID, sessionID string) ([]SessionProperty, error) {
+	if websiteID == "" || sessionID == "" {
+		return nil, errors.New("website-id and session-id are required")
+	}
+
+	var resp []SessionProperty
+	if err := s.client.get(ctx, sessionPath(websiteID, sessionID)+"/properties", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *SessionsService) Stats(ctx context.Context, websiteID string, params url.Values) (*SessionStats, error) {
+	if websiteID == "" {
+		return nil, errors.New("website-id is required")
+	}
+
+	resp := &SessionStats{}
+	if err := s.client.get(ctx, websitePath(websiteID)+"/sessions/stats", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func sessionPath(websiteID, sessionID string) string {
+	return websitePath(websiteID) + "/sessions/" + escape(sessionID)
+}
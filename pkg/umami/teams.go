@@ -0,0 +1,197 @@
+package umami
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type TeamsService struct {
+	client *Client
+}
+
+const (
+	TeamRoleOwner    = "team-owner"
+	TeamRoleManager  = "team-manager"
+	TeamRoleMember   = "team-member"
+	TeamRoleViewOnly = "team-view-only"
+)
+
+// TeamRoles lists the roles a team member can hold.
+var TeamRoles = []string{TeamRoleOwner, TeamRoleManager, TeamRoleMember, TeamRoleViewOnly}
+
+type Team struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	AccessCode string    `json:"accessCode,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type TeamUser struct {
+	ID        string    `json:"id"`
+	TeamID    string    `json:"teamId"`
+	UserID    string    `json:"userId"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+	User      struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+}
+
+// TeamParams holds the fields accepted when creating or updating a team.
+type TeamParams struct {
+	Name       string `json:"name,omitempty"`
+	AccessCode string `json:"accessCode,omitempty"`
+}
+
+func (s *TeamsService) List(ctx context.Context, params ListParams) (*ListResult[Team], error) {
+	return list[Team](ctx, s.client, "/teams", nil, params)
+}
+
+func (s *TeamsService) Get(ctx context.Context, teamID string) (*Team, error) {
+	if teamID == "" {
+		return nil, errors.New("team-id is required")
+	}
+
+	resp := &Team{}
+	if err := s.client.get(ctx, teamPath(teamID), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TeamsService) Create(ctx context.Context, name string) (*Team, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	resp := &Team{}
+	if err := s.client.post(ctx, "/teams", TeamParams{Name: name}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TeamsService) Update(ctx context.Context, teamID string, params TeamParams) (*Team, error) {
+	if teamID == "" {
+		return nil, errors.New("team-id is required")
+	}
+
+	resp := &Team{}
+	if err := s.client.post(ctx, teamPath(teamID), params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TeamsService) Delete(ctx context.Context, teamID string) error {
+	if teamID == "" {
+		return errors.New("team-id is required")
+	}
+	return s.client.delete(ctx, teamPath(teamID))
+}
+
+// Join adds the current user to the team that owns accessCode.
+func (s *TeamsService) Join(ctx context.Context, accessCode string) (*Team, error) {
+	if accessCode == "" {
+		return nil, errors.New("access code is required")
+	}
+
+	resp := &Team{}
+	if err := s.client.post(ctx, "/teams/join", TeamParams{AccessCode: accessCode}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TeamsService) Users(ctx context.Context, teamID string, params ListParams) (*ListResult[TeamUser], error) {
+	if teamID == "" {
+		return nil, errors.New("team-id is required")
+	}
+	return list[TeamUser](ctx, s.client, teamPath(teamID)+"/users", nil, params)
+}
+
+func (s *TeamsService) AddUser(ctx context.Context, teamID, userID, role string) (*TeamUser, error) {
+	if teamID == "" || userID == "" {
+		return nil, errors.New("team-id and user-id are required")
+	}
+	if err := ValidateTeamRole(role); err != nil {
+		return nil, err
+	}
+
+	body := struct {
+		UserID string `json:"userId"`
+		Role   string `json:"role"`
+	}{UserID: userID, Role: role}
+	resp := &TeamUser{}
+	if err := s.client.post(ctx, teamPath(teamID)+"/users", body, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TeamsService) UpdateUserRole(ctx context.Context, teamID, userID, role string) error {
+	if teamID == "" || userID == "" {
+		return errors.New("team-id and user-id are required")
+	}
+	if err := ValidateTeamRole(role); err != nil {
+		return err
+	}
+
+	body := struct {
+		Role string `json:"role"`
+	}{Role: role}
+	return s.client.post(ctx, teamPath(teamID)+"/users/"+escape(userID), body, nil)
+}
+
+func (s *TeamsService) RemoveUser(ctx context.Context, teamID, userID string) error {
+	if teamID == "" || userID == "" {
+		return errors.New("team-id and user-id are required")
+	}
+	return s.client.delete(ctx, teamPath(teamID)+"/users/"+escape(userID))
+}
+
+func (s *TeamsService) Websites(ctx context.Context, teamID string, params ListParams) (*ListResult[Website], error) {
+	if teamID == "" {
+		return nil, errors.New("team-id is required")
+	}
+	return list[Website](ctx, s.client, teamPath(teamID)+"/websites", nil, params)
+}
+
+// AddWebsites moves the given websites into the team.
+func (s *TeamsService) AddWebsites(ctx context.Context, teamID string, websiteIDs ...string) error {
+	if teamID == "" {
+		return errors.New("team-id is required")
+	}
+	if len(websiteIDs) == 0 {
+		return errors.New("at least one website-id is required")
+	}
+
+	body := struct {
+		WebsiteIDs []string `json:"websiteIds"`
+	}{WebsiteIDs: websiteIDs}
+	return s.client.post(ctx, teamPath(teamID)+"/websites", body, nil)
+}
+
+func (s *TeamsService) RemoveWebsite(ctx context.Context, teamID, websiteID string) error {
+	if teamID == "" || websiteID == "" {
+		return errors.New("team-id and website-id are required")
+	}
+	return s.client.delete(ctx, teamPath(teamID)+"/websites/"+escape(websiteID))
+}
+
+func ValidateTeamRole(role string) error {
+	for _, r := range TeamRoles {
+		if role == r {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid team role %q (%s)", role, strings.Join(TeamRoles, "|"))
+}
+
+func teamPath(teamID string) string {
+	return "/teams/" + escape(teamID)
+}
@@ -0,0 +1,156 @@
+// Package umami is a typed Go client for the Umami Analytics API.
+//
+// It wraps the HTTP transport used by the umami CLI and exposes the same
+// endpoints as concrete request and response types:
+//
+//	api, err := umami.New("https://analytics.example.com/api", token)
+//	if err != nil {
+//		return err
+//	}
+//	websites, err := api.Websites.List(ctx, umami.ListParams{})
+//	if err != nil {
+//		return err
+//	}
+//	stats, err := api.Analytics.Stats(ctx, websites.Data[0].ID, umami.QueryParams{
+//		StartAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+//		EndAt:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+//	})
+package umami
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/yborunov/umami-cli/internal/client"
+)
+
+type Client struct {
+	api *client.Client
+
+	Auth      *AuthService
+	Websites  *WebsitesService
+	Teams     *TeamsService
+	Analytics *AnalyticsService
+	Users     *UsersService
+	Me        *MeService
+	Sessions  *SessionsService
+	EventData *EventDataService
+	Reports   *ReportsService
+	Collector *CollectorService
+}
+
+type (
+	Option      = client.Option
+	RetryPolicy = client.RetryPolicy
+	// APIError describes a 4xx or 5xx response; match it with errors.As.
+	APIError = client.APIError
+	// Cache is an on-disk cache of GET responses for ranges in the past.
+	Cache      = client.Cache
+	CacheStats = client.CacheStats
+)
+
+// ErrMissingToken is returned when an authenticated endpoint is called
+// without a token.
+var ErrMissingToken = client.ErrMissingToken
+
+// SettleWindow is how long a range must have ended before its results are
+// treated as final: cached responses and complete store snapshots.
+const SettleWindow = client.SettleWindow
+
+// DefaultRetryPolicy retries idempotent requests up to three times in total.
+var DefaultRetryPolicy = client.DefaultRetryPolicy
+
+// WithRetry sets how idempotent requests are retried after network errors,
+// 429 and 5xx responses.
+func WithRetry(policy RetryPolicy) Option {
+	return client.WithRetry(policy)
+}
+
+// WithTimeout limits each HTTP attempt to timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return client.WithTimeout(timeout)
+}
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return client.WithHTTPClient(httpClient)
+}
+
+// WithCache serves GET requests whose endAt lies in the past from cache,
+// storing successful responses for the cache's TTL.
+func WithCache(cache *Cache) Option {
+	return client.WithCache(cache)
+}
+
+// NewCache returns a cache in dir, or in DefaultCacheDir when dir is empty.
+func NewCache(dir string, ttl time.Duration) (*Cache, error) {
+	return client.NewCache(dir, ttl)
+}
+
+// DefaultCacheDir is umami-cli/http in the user cache directory.
+func DefaultCacheDir() (string, error) {
+	return client.DefaultCacheDir()
+}
+
+// New returns a client for the Umami API rooted at endpoint, which must
+// include the scheme and the /api prefix.
+func New(endpoint, token string, opts ...Option) (*Client, error) {
+	api, err := client.New(endpoint, token, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(api), nil
+}
+
+func newClient(api *client.Client) *Client {
+	c := &Client{api: api}
+	c.Auth = &AuthService{client: c}
+	c.Websites = &WebsitesService{client: c}
+	c.Teams = &TeamsService{client: c}
+	c.Analytics = &AnalyticsService{client: c}
+	c.Users = &UsersService{client: c}
+	c.Me = &MeService{client: c}
+	c.Sessions = &SessionsService{client: c}
+	c.EventData = &EventDataService{client: c}
+	c.Reports = &ReportsService{client: c}
+	c.Collector = &CollectorService{client: c}
+	return c
+}
+
+// WithToken returns a copy of the client that authenticates with token.
+func (c *Client) WithToken(token string) *Client {
+	return newClient(c.api.WithToken(token))
+}
+
+// WithUserAgent returns a copy of the client that sends ua as the
+// User-Agent header.
+func (c *Client) WithUserAgent(ua string) *Client {
+	return newClient(c.api.WithUserAgent(ua))
+}
+
+func (c *Client) get(ctx context.Context, p string, q url.Values, out any) error {
+	_, err := c.api.Do(ctx, "GET", withQuery(p, q), nil, out, true)
+	return err
+}
+
+func (c *Client) post(ctx context.Context, p string, body, out any) error {
+	_, err := c.api.Do(ctx, "POST", p, body, out, true)
+	return err
+}
+
+func (c *Client) delete(ctx context.Context, p string) error {
+	_, err := c.api.Do(ctx, "DELETE", p, nil, nil, true)
+	return err
+}
+
+func withQuery(p string, q url.Values) string {
+	if len(q) == 0 {
+		return p
+	}
+	return p + "?" + q.Encode()
+}
+
+func escape(id string) string {
+	return url.PathEscape(id)
+}
@@ -0,0 +1,146 @@
+package umami
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UsersService wraps the user administration endpoints, which require an
+// admin token.
+type UsersService struct {
+	client *Client
+}
+
+const (
+	UserRoleAdmin    = "admin"
+	UserRoleUser     = "user"
+	UserRoleViewOnly = "view-only"
+)
+
+// UserRoles lists the instance-wide roles a user can hold.
+var UserRoles = []string{UserRoleAdmin, UserRoleUser, UserRoleViewOnly}
+
+type User struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	IsAdmin   bool      `json:"isAdmin,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// UserParams holds the fields accepted when creating or updating a user.
+type UserParams struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Role     string `json:"role,omitempty"`
+}
+
+func (s *UsersService) List(ctx context.Context, params ListParams) (*ListResult[User], error) {
+	return list[User](ctx, s.client, "/admin/users", nil, params)
+}
+
+func (s *UsersService) Get(ctx context.Context, userID string) (*User, error) {
+	if userID == "" {
+		return nil, errors.New("user-id is required")
+	}
+
+	resp := &User{}
+	if err := s.client.get(ctx, userPath(userID), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *UsersService) Create(ctx context.Context, params UserParams) (*User, error) {
+	if params.Username == "" || params.Password == "" {
+		return nil, errors.New("username and password are required")
+	}
+	if err := ValidateUserRole(params.Role); err != nil {
+		return nil, err
+	}
+
+	resp := &User{}
+	if err := s.client.post(ctx, "/users", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *UsersService) Update(ctx context.Context, userID string, params UserParams) (*User, error) {
+	if userID == "" {
+		return nil, errors.New("user-id is required")
+	}
+	if params.Role != "" {
+		if err := ValidateUserRole(params.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &User{}
+	if err := s.client.post(ctx, userPath(userID), params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *UsersService) Delete(ctx context.Context, userID string) error {
+	if userID == "" {
+		return errors.New("user-id is required")
+	}
+	return s.client.delete(ctx, userPath(userID))
+}
+
+func (s *UsersService) Websites(ctx context.Context, userID string, params ListParams) (*ListResult[Website], error) {
+	if userID == "" {
+		return nil, errors.New("user-id is required")
+	}
+	return list[Website](ctx, s.client, userPath(userID)+"/websites", nil, params)
+}
+
+func (s *UsersService) Teams(ctx context.Context, userID string, params ListParams) (*ListResult[Team], error) {
+	if userID == "" {
+		return nil, errors.New("user-id is required")
+	}
+	return list[Team](ctx, s.client, userPath(userID)+"/teams", nil, params)
+}
+
+func ValidateUserRole(role string) error {
+	for _, r := range UserRoles {
+		if role == r {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid user role %q (%s)", role, strings.Join(UserRoles, "|"))
+}
+
+func userPath(userID string) string {
+	return "/users/" + escape(userID)
+}
+
+// MeService wraps the endpoints scoped to the authenticated user.
+type MeService struct {
+	client *Client
+}
+
+type meResponse struct {
+	User User `json:"user"`
+}
+
+func (s *MeService) Get(ctx context.Context) (*User, error) {
+	var resp meResponse
+	if err := s.client.get(ctx, "/me", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.User, nil
+}
+
+func (s *MeService) Websites(ctx context.Context, params ListParams) (*ListResult[Website], error) {
+	return list[Website](ctx, s.client, "/me/websites", nil, params)
+}
+
+func (s *MeService) Teams(ctx context.Context, params ListParams) (*ListResult[Team], error) {
+	return list[Team](ctx, s.client, "/me/teams", nil, params)
+}
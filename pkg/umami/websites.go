@@ -0,0 +1,105 @@
+package umami
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type WebsitesService struct {
+	client *Client
+}
+
+type Website struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Domain    string    `json:"domain"`
+	ShareID   string    `json:"shareId,omitempty"`
+	TeamID    string    `json:"teamId,omitempty"`
+	UserID    string    `json:"userId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebsiteParams holds the fields accepted when creating or updating a
+// website. Empty fields are left out of the request.
+type WebsiteParams struct {
+	Name    string `json:"name,omitempty"`
+	Domain  string `json:"domain,omitempty"`
+	ShareID string `json:"shareId,omitempty"`
+	TeamID  string `json:"teamId,omitempty"`
+	// ClearShareID sends a null share ID, which disables the public
+	// dashboard. It takes precedence over ShareID.
+	ClearShareID bool `json:"-"`
+}
+
+func (p WebsiteParams) MarshalJSON() ([]byte, error) {
+	type params WebsiteParams
+	if !p.ClearShareID {
+		return json.Marshal(params(p))
+	}
+	return json.Marshal(struct {
+		params
+		ShareID *string `json:"shareId"`
+	}{params: params(p)})
+}
+
+func (s *WebsitesService) List(ctx context.Context, params ListParams) (*ListResult[Website], error) {
+	return list[Website](ctx, s.client, "/websites", nil, params)
+}
+
+func (s *WebsitesService) Get(ctx context.Context, websiteID string) (*Website, error) {
+	if websiteID == "" {
+		return nil, errors.New("website-id is required")
+	}
+
+	resp := &Website{}
+	if err := s.client.get(ctx, websitePath(websiteID), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *WebsitesService) Create(ctx context.Context, params WebsiteParams) (*Website, error) {
+	if params.Name == "" || params.Domain == "" {
+		return nil, errors.New("name and domain are required")
+	}
+
+	resp := &Website{}
+	if err := s.client.post(ctx, "/websites", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *WebsitesService) Update(ctx context.Context, websiteID string, params WebsiteParams) (*Website, error) {
+	if websiteID == "" {
+		return nil, errors.New("website-id is required")
+	}
+
+	resp := &Website{}
+	if err := s.client.post(ctx, websitePath(websiteID), params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *WebsitesService) Delete(ctx context.Context, websiteID string) error {
+	if websiteID == "" {
+		return errors.New("website-id is required")
+	}
+	return s.client.delete(ctx, websitePath(websiteID))
+}
+
+// Reset deletes all collected data for a website while keeping the website
+// itself.
+func (s *WebsitesService) Reset(ctx context.Context, websiteID string) error {
+	if websiteID == "" {
+		return errors.New("website-id is required")
+	}
+	return s.client.post(ctx, websitePath(websiteID)+"/reset", nil, nil)
+}
+
+func websitePath(websiteID string) string {
+	return "/websites/" + escape(websiteID)
+}